@@ -0,0 +1,100 @@
+// Command palettegen writes a Go package with a palette's colors as
+// image/color.RGBA variables. It reads Ladle's palette.json as well as every
+// palette file format Ladle can import, and is meant for go:generate.
+//
+// The module path cannot be fetched, so install the command from a checkout
+// of this repository:
+//
+//	go install ./cmd/palettegen
+//
+// and call it from any module with the binary on the PATH:
+//
+//	//go:generate palettegen -in brand.ase -out colors.go
+//
+// Without -in the active palette of the local Ladle installation is used.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"ladle-color-picker/internal/color"
+	"ladle-color-picker/internal/swatches"
+)
+
+func main() {
+	in := flag.String("in", "", "palette file; defaults to Ladle's own palette file")
+	name := flag.String("palette", "", "palette to use from a Ladle palette file; defaults to the active one")
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "package name; defaults to $GOPACKAGE or one derived from the palette name")
+	out := flag.String("out", "", "output file; defaults to standard output")
+	flag.Parse()
+
+	log.SetFlags(0)
+	log.SetPrefix("palettegen: ")
+
+	p, err := readPalette(*in, *name)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *pkg == "" {
+		*pkg = swatches.GoPackageName(p.Name)
+	}
+
+	var buf bytes.Buffer
+	if err := swatches.EncodeGo(&buf, p, *pkg); err != nil {
+		log.Fatal(err)
+	}
+
+	if *out == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := os.WriteFile(*out, buf.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readPalette loads a Ladle palette file or any importable palette format
+func readPalette(file, name string) (*swatches.Palette, error) {
+	if file == "" {
+		ladle := color.NewPalette()
+		if err := ladle.Load(); err != nil {
+			return nil, fmt.Errorf("reading Ladle's palette: %w", err)
+		}
+		return pickPalette(ladle, name)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	//Ladle's own files are recognised by their palette list
+	var probe struct {
+		Palettes    json.RawMessage `json:"palettes"`
+		SavedColors json.RawMessage `json:"saved_colors"`
+	}
+	if json.Unmarshal(data, &probe) == nil && (probe.Palettes != nil || probe.SavedColors != nil) {
+		ladle := color.NewPalette()
+		if err := ladle.Decode(data); err != nil {
+			return nil, err
+		}
+		return pickPalette(ladle, name)
+	}
+
+	return swatches.Decode(file, bytes.NewReader(data))
+}
+
+func pickPalette(ladle *color.Palette, name string) (*swatches.Palette, error) {
+	named := ladle.Active()
+	if name != "" {
+		if named = ladle.Find(name); named == nil {
+			return nil, fmt.Errorf("%w: %s", color.ErrPaletteNotFound, name)
+		}
+	}
+	return swatches.FromNamed(named), nil
+}
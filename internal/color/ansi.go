@@ -0,0 +1,118 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+// ANSIMode selects how many colors a terminal escape sequence may use
+type ANSIMode int
+
+const (
+	ANSITrueColor ANSIMode = iota //24-bit
+	ANSI256                       //xterm-256 palette
+	ANSI16                        //basic and bright colors
+)
+
+// ANSIReset restores the terminal's default colors
+const ANSIReset = "\x1b[0m"
+
+// ANSI16Palette holds the xterm default values of the 16 basic colors
+var ANSI16Palette = []*Color{
+	NewColor(0, 0, 0),       //Black
+	NewColor(205, 0, 0),     //Red
+	NewColor(0, 205, 0),     //Green
+	NewColor(205, 205, 0),   //Yellow
+	NewColor(0, 0, 238),     //Blue
+	NewColor(205, 0, 205),   //Magenta
+	NewColor(0, 205, 205),   //Cyan
+	NewColor(229, 229, 229), //White
+	NewColor(127, 127, 127), //Bright black
+	NewColor(255, 0, 0),     //Bright red
+	NewColor(0, 255, 0),     //Bright green
+	NewColor(255, 255, 0),   //Bright yellow
+	NewColor(92, 92, 255),   //Bright blue
+	NewColor(255, 0, 255),   //Bright magenta
+	NewColor(0, 255, 255),   //Bright cyan
+	NewColor(255, 255, 255), //Bright white
+}
+
+// Xterm256Palette holds the full xterm-256 palette: the 16 basic colors,
+// a 6x6x6 color cube and a 24 step grayscale ramp
+var Xterm256Palette = buildXterm256()
+
+func buildXterm256() []*Color {
+	palette := append([]*Color(nil), ANSI16Palette...)
+
+	levels := []uint8{0, 95, 135, 175, 215, 255}
+	for _, r := range levels {
+		for _, g := range levels {
+			for _, b := range levels {
+				palette = append(palette, NewColor(r, g, b))
+			}
+		}
+	}
+
+	for i := 0; i < 24; i++ {
+		v := uint8(8 + i*10)
+		palette = append(palette, NewColor(v, v, v))
+	}
+
+	return palette
+}
+
+// Distance returns the perceptual distance between two colors (Euclidean in OKLab)
+func Distance(a, b *Color) float64 {
+	x, y := a.To(OKLab), b.To(OKLab)
+	return math.Sqrt((x[0]-y[0])*(x[0]-y[0]) + (x[1]-y[1])*(x[1]-y[1]) + (x[2]-y[2])*(x[2]-y[2]))
+}
+
+// Nearest returns the index of the perceptually closest color in the palette
+func (c *Color) Nearest(palette []*Color) int {
+	best, bestDist := 0, math.Inf(1)
+	for i, p := range palette {
+		if d := Distance(c, p); d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// NearestANSI16 returns the closest basic terminal color index (0-15)
+func (c *Color) NearestANSI16() int {
+	return c.Nearest(ANSI16Palette)
+}
+
+// NearestXterm256 returns the closest xterm-256 palette index (16-255).
+// Indices 0-15 are skipped because terminal themes redefine them; use
+// NearestANSI16 for those.
+func (c *Color) NearestXterm256() int {
+	return 16 + c.Nearest(Xterm256Palette[16:])
+}
+
+// ANSIEscape returns the escape sequence that sets the foreground,
+// or the background, to this color in the given mode
+func (c *Color) ANSIEscape(mode ANSIMode, background bool) string {
+	switch mode {
+	case ANSI256:
+		if background {
+			return fmt.Sprintf("\x1b[48;5;%dm", c.NearestXterm256())
+		}
+		return fmt.Sprintf("\x1b[38;5;%dm", c.NearestXterm256())
+	case ANSI16:
+		i := c.NearestANSI16()
+		code := 30 + i
+		if i >= 8 {
+			code = 90 + i - 8
+		}
+		if background {
+			code += 10
+		}
+		return fmt.Sprintf("\x1b[%dm", code)
+	default:
+		if background {
+			return fmt.Sprintf("\x1b[48;2;%d;%d;%dm", c.R, c.G, c.B)
+		}
+		return fmt.Sprintf("\x1b[38;2;%d;%d;%dm", c.R, c.G, c.B)
+	}
+}
@@ -0,0 +1,27 @@
+package color
+
+import "math"
+
+// ToCMYK returns naive (profile-free) CMYK values in 0..1
+func (c *Color) ToCMYK() [4]float64 {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	k := 1 - math.Max(math.Max(r, g), b)
+	if k == 1 {
+		return [4]float64{0, 0, 0, 1}
+	}
+	return [4]float64{
+		(1 - r - k) / (1 - k),
+		(1 - g - k) / (1 - k),
+		(1 - b - k) / (1 - k),
+		k,
+	}
+}
+
+// FromCMYK converts naive CMYK values in 0..1 to an sRGB color
+func FromCMYK(c, m, y, k float64) *Color {
+	return NewColor(
+		clampByte((1-c)*(1-k)),
+		clampByte((1-m)*(1-k)),
+		clampByte((1-y)*(1-k)),
+	)
+}
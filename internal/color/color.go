@@ -3,7 +3,7 @@ package color
 import (
 	"fmt"
 	"image/color"
-	"math"
+	"strings"
 )
 
 // Color represents an RGB color with conversion methods
@@ -30,6 +30,16 @@ func NewColorHex(hex string) (*Color, error) {
 	return &Color{R: r, G: g, B: b}, nil
 }
 
+// ParseHex is a lenient NewColorHex that accepts "#rrggbb", "rrggbb",
+// "#rgb" and "rgb" in any case, with surrounding whitespace
+func ParseHex(text string) (*Color, error) {
+	hex := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(text), "#"))
+	if len(hex) == 3 {
+		hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+	}
+	return NewColorHex("#" + hex)
+}
+
 // ToHex returns the color as a hex string like "#ff0000"
 func (c *Color) ToHex() string {
 	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
@@ -42,8 +52,7 @@ func (c *Color) ToRGB() string {
 
 // ToHSL return HSL string like "hsl(0, 100%, 50%)"
 func (c *Color) ToHSL() string {
-	h, s, l := c.rgbToHsl()
-	return fmt.Sprintf("hsl(%.0f, %.0f%%, %.0f%%)", h, s*100, l*100)
+	return c.Format(HSL)
 }
 
 // ToFyneColor converts to Fyne's color format
@@ -51,43 +60,6 @@ func (c *Color) ToFyneColor() color.RGBA {
 	return color.RGBA{R: c.R, G: c.G, B: c.B, A: 255}
 }
 
-// rgbToHsl converts RGB to HSL values
-func (c *Color) rgbToHsl() (float64, float64, float64) {
-	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
-
-	max := math.Max(math.Max(r, g), b)
-	min := math.Min(math.Min(r, g), b)
-
-	l := (max + min) / 2
-	var h, s float64
-
-	if max == min {
-		h, s = 0, 0 //achromatic
-	} else {
-		d := max - min
-		if l > 0.5 {
-			s = d / (2 - max - min)
-		} else {
-			s = d / (max + min)
-		}
-
-		switch max {
-		case r:
-			h = (g - b) / d
-			if g < b {
-				h += 6
-			}
-		case g:
-			h = (b-r)/d + 2
-		case b:
-			h = (r-g)/d + 4
-		}
-		h /= 6
-	}
-
-	return h * 360, s, l
-}
-
 // The GetPresetColors returns common preset colors
 func GetPresetColors() []*Color {
 	return []*Color{
@@ -0,0 +1,33 @@
+package color
+
+import "math"
+
+// Luminance returns the WCAG relative luminance, 0 for black to 1 for white
+func (c *Color) Luminance() float64 {
+	linear := func(v uint8) float64 {
+		s := float64(v) / 255
+		if s <= 0.04045 {
+			return s / 12.92
+		}
+		return math.Pow((s+0.055)/1.055, 2.4)
+	}
+	return 0.2126*linear(c.R) + 0.7152*linear(c.G) + 0.0722*linear(c.B)
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two colors, from 1 to 21
+func ContrastRatio(a, b *Color) float64 {
+	la, lb := a.Luminance(), b.Luminance()
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// ReadableText returns black or white, whichever contrasts more with the color
+func (c *Color) ReadableText() *Color {
+	black, white := NewColor(0, 0, 0), NewColor(255, 255, 255)
+	if ContrastRatio(c, black) >= ContrastRatio(c, white) {
+		return black
+	}
+	return white
+}
@@ -0,0 +1,126 @@
+package color
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultDuplicateThreshold is the OKLab distance under which two colors are
+// treated as near-duplicates, roughly one just noticeable difference
+const DefaultDuplicateThreshold = 0.02
+
+// ErrInvalidThreshold is returned for negative duplicate thresholds
+var ErrInvalidThreshold = errors.New("invalid duplicate threshold")
+
+// Match is a saved color together with its distance to another color
+type Match struct {
+	Saved    *SavedColor
+	Distance float64
+}
+
+// SetDuplicateThreshold changes the near-duplicate distance; zero turns the warning off
+func (p *Palette) SetDuplicateThreshold(threshold float64) error {
+	if threshold < 0 {
+		return ErrInvalidThreshold
+	}
+	p.DuplicateThreshold = threshold
+	return nil
+}
+
+// NearDuplicates returns the colors in the active palette that are within
+// the duplicate threshold of hex but not identical, closest first
+func (p *Palette) NearDuplicates(hex string) []Match {
+	col, err := NewColorHex(hex)
+	if err != nil || p.DuplicateThreshold == 0 {
+		return nil
+	}
+
+	var matches []Match
+	for _, saved := range p.Saved() {
+		other, err := NewColorHex(saved.Hex)
+		if err != nil || saved.Hex == hex {
+			continue
+		}
+		if d := Distance(col, other); d < p.DuplicateThreshold {
+			matches = append(matches, Match{Saved: saved, Distance: d})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Distance < matches[j].Distance })
+	return matches
+}
+
+// ClusterSaved groups the active palette's near-duplicates around the color
+// to keep. Going through the palette in order, each color not yet grouped
+// gathers the later ungrouped colors within the threshold of it, so every
+// member is close to the first entry rather than only to a neighbor. Only
+// groups of two or more are returned.
+func (p *Palette) ClusterSaved(threshold float64) [][]*SavedColor {
+	colors := p.Saved()
+	parsed := make([]*Color, len(colors))
+	for i, saved := range colors {
+		parsed[i], _ = NewColorHex(saved.Hex)
+	}
+
+	grouped := make([]bool, len(colors))
+	var clusters [][]*SavedColor
+	for i, keep := range colors {
+		if grouped[i] || parsed[i] == nil {
+			continue
+		}
+
+		group := []*SavedColor{keep}
+		for j := i + 1; j < len(colors); j++ {
+			if grouped[j] || parsed[j] == nil {
+				continue
+			}
+			if Distance(parsed[i], parsed[j]) < threshold {
+				grouped[j] = true
+				group = append(group, colors[j])
+			}
+		}
+		if len(group) > 1 {
+			grouped[i] = true
+			clusters = append(clusters, group)
+		}
+	}
+	return clusters
+}
+
+// MergeSaved folds other colors of the active palette into keep: their tags
+// and notes are added to keep, the name is kept unless keep has none, and
+// the others are removed
+func (p *Palette) MergeSaved(keep string, others []string) error {
+	target := p.Active().Find(keep)
+	if target == nil {
+		return ErrColorNotSaved
+	}
+
+	for _, hex := range others {
+		if hex == keep {
+			continue
+		}
+		other := p.Active().Find(hex)
+		if other == nil {
+			return ErrColorNotSaved
+		}
+
+		if target.Name == "" {
+			target.Name = other.Name
+		}
+		for _, tag := range other.Tags {
+			if !target.HasTag(tag) {
+				target.Tags = append(target.Tags, tag)
+			}
+		}
+		if other.Notes != "" {
+			target.Notes = strings.TrimSpace(target.Notes + "\n" + other.Notes)
+		}
+		p.RemoveSaved(hex)
+	}
+
+	target.Updated = time.Now()
+	return nil
+}
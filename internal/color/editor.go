@@ -0,0 +1,222 @@
+package color
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+)
+
+const editorThemeFileName = "editor-theme.json"
+
+// EditorRole is a semantic slot of an editor color scheme
+type EditorRole struct {
+	ID    string
+	Label string
+
+	// Hue is the OKLCH hue auto-assignment looks for, negative for roles
+	// that are picked by lightness or chroma instead
+	Hue float64
+
+	// Default is used until the user picks a color (VS Code Dark+)
+	Default string
+}
+
+// Editor roles
+const (
+	RoleBackground    = "background"
+	RoleForeground    = "foreground"
+	RoleSelection     = "selection"
+	RoleLineHighlight = "line_highlight"
+	RoleCursor        = "cursor"
+	RoleComment       = "comment"
+	RoleKeyword       = "keyword"
+	RoleString        = "string"
+	RoleNumber        = "number"
+	RoleFunction      = "function"
+	RoleType          = "type"
+	RoleVariable      = "variable"
+	RoleConstant      = "constant"
+	RoleOperator      = "operator"
+	RoleError         = "error"
+	RoleWarning       = "warning"
+)
+
+// EditorRoles lists every role in display order
+var EditorRoles = []EditorRole{
+	{RoleBackground, "Background", -1, "#1e1e1e"},
+	{RoleForeground, "Foreground", -1, "#d4d4d4"},
+	{RoleSelection, "Selection", 250, "#264f78"},
+	{RoleLineHighlight, "Line Highlight", -1, "#2a2d2e"},
+	{RoleCursor, "Cursor", -1, "#aeafad"},
+	{RoleComment, "Comment", -1, "#6a9955"},
+	{RoleKeyword, "Keyword", 300, "#569cd6"},
+	{RoleString, "String", 140, "#ce9178"},
+	{RoleNumber, "Number", 60, "#b5cea8"},
+	{RoleFunction, "Function", 95, "#dcdcaa"},
+	{RoleType, "Type", 180, "#4ec9b0"},
+	{RoleVariable, "Variable", 230, "#9cdcfe"},
+	{RoleConstant, "Constant", 210, "#4fc1ff"},
+	{RoleOperator, "Operator", -1, "#d4d4d4"},
+	{RoleError, "Error", 25, "#f44747"},
+	{RoleWarning, "Warning", 80, "#cca700"},
+}
+
+// EditorTheme maps editor roles to colors
+type EditorTheme struct {
+	Name   string            `json:"name"`
+	Colors map[string]string `json:"colors"`
+}
+
+// NewEditorTheme creates a theme with every role at its default
+func NewEditorTheme() *EditorTheme {
+	t := &EditorTheme{Name: "Ladle", Colors: make(map[string]string)}
+	for _, role := range EditorRoles {
+		t.Colors[role.ID] = role.Default
+	}
+	return t
+}
+
+// Role returns the color of a role, falling back to its default
+func (t *EditorTheme) Role(id string) *Color {
+	if col, err := NewColorHex(t.Colors[id]); err == nil {
+		return col
+	}
+	for _, role := range EditorRoles {
+		if role.ID == id {
+			col, _ := NewColorHex(role.Default)
+			return col
+		}
+	}
+	return NewColor(0, 0, 0)
+}
+
+// SetRole assigns a color to a role
+func (t *EditorTheme) SetRole(id, hex string) error {
+	if _, err := NewColorHex(hex); err != nil {
+		return err
+	}
+	t.Colors[id] = hex
+	return nil
+}
+
+// Dark reports whether the background is dark, so editors pick matching UI chrome
+func (t *EditorTheme) Dark() bool {
+	return t.Role(RoleBackground).To(OKLab)[0] < 0.5
+}
+
+// AutoAssign fills every role from the given colors. The darkest color
+// becomes the background (the lightest if dark is false), the color with the
+// most contrast to it the foreground, a muted one of middling lightness the
+// comments, and the hued roles take the color whose hue is closest to the role's.
+// Roles stay unchanged when colors is empty.
+func (t *EditorTheme) AutoAssign(colors []*Color, dark bool) {
+	if len(colors) == 0 {
+		return
+	}
+
+	lch := make([]Values, len(colors))
+	for i, c := range colors {
+		lch[i] = c.To(OKLCH)
+	}
+	pick := func(score func(i int) float64) *Color {
+		best := 0
+		for i := range colors {
+			if score(i) < score(best) {
+				best = i
+			}
+		}
+		return colors[best]
+	}
+
+	bg := pick(func(i int) float64 {
+		if dark {
+			return lch[i][0]
+		}
+		return -lch[i][0]
+	})
+	fg := pick(func(i int) float64 { return -Distance(colors[i], bg) })
+	bgL := bg.To(OKLCH)[0]
+
+	t.Colors[RoleBackground] = bg.ToHex()
+	t.Colors[RoleForeground] = fg.ToHex()
+	t.Colors[RoleCursor] = fg.ToHex()
+	t.Colors[RoleOperator] = fg.ToHex()
+	fgL := fg.To(OKLCH)[0]
+	t.Colors[RoleComment] = pick(func(i int) float64 {
+		//Muted, and halfway between background and foreground so it recedes
+		score := lch[i][1] + math.Abs(lch[i][0]-(bgL+fgL)/2)/2
+		if *colors[i] == *bg || *colors[i] == *fg {
+			score++
+		}
+		return score
+	}).ToHex()
+
+	//The line highlight is a slight step from the background towards the foreground
+	t.Colors[RoleLineHighlight] = mix(bg, fg, 0.06).ToHex()
+
+	for _, role := range EditorRoles {
+		if role.Hue < 0 {
+			continue
+		}
+		t.Colors[role.ID] = pick(func(i int) float64 {
+			d := math.Abs(math.Mod(lch[i][2]-role.Hue+540, 360) - 180)
+			//Prefer colorful candidates that stand out from the background
+			return d/180 - lch[i][1] + math.Max(0, 0.2-math.Abs(lch[i][0]-bgL))
+		}).ToHex()
+	}
+	//Selections need to sit behind text, so tone the pick down towards the background
+	t.Colors[RoleSelection] = mix(bg, t.Role(RoleSelection), 0.35).ToHex()
+}
+
+// mix interpolates between two colors in OKLab
+func mix(a, b *Color, amount float64) *Color {
+	va, vb := a.To(OKLab), b.To(OKLab)
+	var v Values
+	for i := range v {
+		v[i] = va[i] + (vb[i]-va[i])*amount
+	}
+	return FromSpace(v, OKLab)
+}
+
+// SaveEditorTheme writes the editor theme next to the palette file
+func SaveEditorTheme(t *EditorTheme) error {
+	file, err := configFile(editorThemeFileName)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(t, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, data, 0644)
+}
+
+// LoadEditorTheme reads the editor theme from the config directory.
+// Missing or invalid roles keep their defaults.
+func LoadEditorTheme() (*EditorTheme, error) {
+	file, err := configFile(editorThemeFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err //File doesn't exist yet
+	}
+
+	var loaded EditorTheme
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return nil, err
+	}
+
+	t := NewEditorTheme()
+	if loaded.Name != "" {
+		t.Name = loaded.Name
+	}
+	for _, role := range EditorRoles {
+		t.SetRole(role.ID, loaded.Colors[role.ID])
+	}
+	return t, nil
+}
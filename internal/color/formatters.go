@@ -0,0 +1,75 @@
+package color
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Formatter renders a color as text for a language, platform or notation
+type Formatter struct {
+	Name   string
+	Format func(c *Color) string
+}
+
+// Formatters lists every built-in formatter in menu order
+var Formatters = []*Formatter{
+	{"CSS HEX", (*Color).ToHex},
+	{"CSS RGB", (*Color).ToRGB},
+	{"CSS HSL", (*Color).ToHSL},
+	{"CSS OKLCH", func(c *Color) string { return c.Format(OKLCH) }},
+	{"CSS Display P3", func(c *Color) string { return c.In(DisplayP3).ToCSS() }},
+
+	{"Go color.RGBA", func(c *Color) string {
+		return fmt.Sprintf("color.RGBA{R: 0x%02x, G: 0x%02x, B: 0x%02x, A: 0xff}", c.R, c.G, c.B)
+	}},
+	{"Swift UIColor", func(c *Color) string {
+		r, g, b := c.unit()
+		return fmt.Sprintf("UIColor(red: %s, green: %s, blue: %s, alpha: 1.0)", r, g, b)
+	}},
+	{"SwiftUI Color", func(c *Color) string {
+		r, g, b := c.unit()
+		return fmt.Sprintf("Color(red: %s, green: %s, blue: %s)", r, g, b)
+	}},
+	{"Kotlin ARGB Int", func(c *Color) string {
+		return fmt.Sprintf("0x%s.toInt()", c.argbHex())
+	}},
+	{"Android XML", func(c *Color) string {
+		return fmt.Sprintf(`<color name="color_%02x%02x%02x">#%02X%02X%02X</color>`, c.R, c.G, c.B, c.R, c.G, c.B)
+	}},
+	{"Flutter Color", func(c *Color) string {
+		return fmt.Sprintf("Color(0x%s)", c.argbHex())
+	}},
+	{"C# Color.FromArgb", func(c *Color) string {
+		return fmt.Sprintf("Color.FromArgb(255, %d, %d, %d)", c.R, c.G, c.B)
+	}},
+	{"Java AWT Color", func(c *Color) string {
+		return fmt.Sprintf("new Color(%d, %d, %d)", c.R, c.G, c.B)
+	}},
+	{"Unity Color", func(c *Color) string {
+		r, g, b := c.unit()
+		return fmt.Sprintf("new Color(%sf, %sf, %sf, 1f)", r, g, b)
+	}},
+}
+
+// FormatterByName looks up a formatter
+func FormatterByName(name string) (*Formatter, bool) {
+	for _, f := range Formatters {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// unit returns the channels as 0..1 decimals with three digits
+func (c *Color) unit() (string, string, string) {
+	f := func(v uint8) string {
+		return strconv.FormatFloat(float64(v)/255, 'f', 3, 64)
+	}
+	return f(c.R), f(c.G), f(c.B)
+}
+
+// argbHex returns the opaque color as uppercase AARRGGBB
+func (c *Color) argbHex() string {
+	return fmt.Sprintf("FF%02X%02X%02X", c.R, c.G, c.B)
+}
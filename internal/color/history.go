@@ -0,0 +1,122 @@
+package color
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+const historyFileName = "history.jsonl"
+
+// Actions that can produce a history entry
+const (
+	ActionSlider  = "slider"
+	ActionPreset  = "preset"
+	ActionRecent  = "recent"
+	ActionSaved   = "saved"
+	ActionPaste   = "paste"
+	ActionWide    = "wide gamut"
+	ActionHistory = "history"
+	ActionTheme   = "terminal theme"
+)
+
+// HistoryEntry is one committed color in the long-term history
+type HistoryEntry struct {
+	Time   time.Time `json:"time"`
+	Hex    string    `json:"hex"`
+	Action string    `json:"action"`
+}
+
+// AppendHistory adds an entry to the history log. The log is append-only:
+// one JSON object per line, never rewritten.
+func AppendHistory(entry HistoryEntry) error {
+	path, err := configFile(historyFileName)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return json.NewEncoder(file).Encode(entry)
+}
+
+// LoadHistory reads the whole history log, oldest first. Lines that cannot
+// be parsed, such as a partially written last line, are skipped.
+func LoadHistory() ([]HistoryEntry, error) {
+	path, err := configFile(historyFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err //File doesn't exist yet
+	}
+	defer file.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// HistoryFilter narrows down history entries. Zero times leave that end of
+// the date range open. When UseHue is set, only chromatic colors whose hue
+// lies between HueFrom and HueTo (degrees, wrapping past 360) match.
+type HistoryFilter struct {
+	From, To       time.Time
+	UseHue         bool
+	HueFrom, HueTo float64
+}
+
+// achromaticSaturation is the HSL saturation below which a color has no meaningful hue
+const achromaticSaturation = 0.05
+
+// Match reports whether an entry passes the filter
+func (f HistoryFilter) Match(entry HistoryEntry) bool {
+	if !f.From.IsZero() && entry.Time.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && entry.Time.After(f.To) {
+		return false
+	}
+
+	if f.UseHue {
+		col, err := NewColorHex(entry.Hex)
+		if err != nil {
+			return false
+		}
+		hsl := col.To(HSL)
+		if hsl[1] < achromaticSaturation {
+			return false
+		}
+		if f.HueFrom <= f.HueTo {
+			return hsl[0] >= f.HueFrom && hsl[0] <= f.HueTo
+		}
+		return hsl[0] >= f.HueFrom || hsl[0] <= f.HueTo
+	}
+
+	return true
+}
+
+// FilterHistory returns the matching entries, newest first
+func FilterHistory(entries []HistoryEntry, f HistoryFilter) []HistoryEntry {
+	var out []HistoryEntry
+	for i := len(entries) - 1; i >= 0; i-- {
+		if f.Match(entries[i]) {
+			out = append(out, entries[i])
+		}
+	}
+	return out
+}
@@ -2,25 +2,91 @@ package color
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
-// Palette function manages saved and recent colors
+const (
+	configDirName   = ".ladle-color-picker"
+	paletteFileName = "palette.json"
+
+	// DefaultPaletteName is used for the first palette and for migrated saved colors
+	DefaultPaletteName = "Default"
+
+	// Unlimited as MaxSaved lets a palette grow without bound
+	Unlimited = 0
+
+	defaultMaxRecent = 8
+	defaultMaxSaved  = 16
+)
+
+// Palette management errors
+var (
+	ErrPaletteExists   = errors.New("a palette with that name already exists")
+	ErrPaletteNotFound = errors.New("palette not found")
+	ErrPaletteName     = errors.New("palette name is empty")
+	ErrLastPalette     = errors.New("cannot delete the last palette")
+	ErrColorNotSaved   = errors.New("color is not in the palette")
+	ErrAlreadySaved    = errors.New("color already saved")
+	ErrPaletteFull     = errors.New("palette is full")
+	ErrInvalidLimit    = errors.New("invalid palette limit")
+)
+
+// NamedPalette is a named list of saved colors
+type NamedPalette struct {
+	Name    string        `json:"name"`
+	Colors  []*SavedColor `json:"colors"`
+	Columns int           `json:"columns,omitempty"` //Layout hint from imported files
+}
+
+// clone returns a deep copy of the palette under the given name
+func (n *NamedPalette) clone(name string) *NamedPalette {
+	colors := make([]*SavedColor, len(n.Colors))
+	for i, saved := range n.Colors {
+		c := *saved
+		c.Tags = append([]string(nil), saved.Tags...)
+		c.CMYK = append([]float64(nil), saved.CMYK...)
+		colors[i] = &c
+	}
+	return &NamedPalette{Name: name, Colors: colors, Columns: n.Columns}
+}
+
+// Find returns the saved entry for a hex code, or nil
+func (n *NamedPalette) Find(hex string) *SavedColor {
+	for _, saved := range n.Colors {
+		if saved.Hex == hex {
+			return saved
+		}
+	}
+	return nil
+}
+
+// Palette function manages recent colors and the named palettes of saved colors
 type Palette struct {
-	RecentColors []string `json:"recent_colors"`
-	SavedColors  []string `json:"saved_colors"`
-	MaxRecent    int      `json:"-"`
-	MaxSaved     int      `json:"-"`
+	RecentColors []string        `json:"recent_colors"`
+	Palettes     []*NamedPalette `json:"palettes"`
+	ActiveName   string          `json:"active_palette"`
+	MaxRecent    int             `json:"max_recent"`
+	MaxSaved     int             `json:"max_saved"`
+
+	// DuplicateThreshold is the OKLab distance for near-duplicate warnings
+	DuplicateThreshold float64 `json:"duplicate_threshold"`
 }
 
 // NewPalette creates a new palette
 func NewPalette() *Palette {
 	return &Palette{
 		RecentColors: make([]string, 0),
-		SavedColors:  make([]string, 0),
-		MaxRecent:    8,
-		MaxSaved:     16,
+		Palettes:     []*NamedPalette{{Name: DefaultPaletteName, Colors: make([]*SavedColor, 0)}},
+		ActiveName:   DefaultPaletteName,
+		MaxRecent:    defaultMaxRecent,
+		MaxSaved:     defaultMaxSaved,
+
+		DuplicateThreshold: DefaultDuplicateThreshold,
 	}
 }
 
@@ -43,69 +109,338 @@ func (p *Palette) AddRecent(hex string) {
 	}
 }
 
-// AddSaved adds a color to saved colors
-func (p *Palette) AddSaved(hex string) bool {
+// Active returns the palette that saved colors go to
+func (p *Palette) Active() *NamedPalette {
+	if active := p.Find(p.ActiveName); active != nil {
+		return active
+	}
+	if len(p.Palettes) == 0 {
+		p.Palettes = []*NamedPalette{{Name: DefaultPaletteName, Colors: make([]*SavedColor, 0)}}
+	}
+	p.ActiveName = p.Palettes[0].Name
+	return p.Palettes[0]
+}
+
+// Saved returns the colors of the active palette
+func (p *Palette) Saved() []*SavedColor {
+	return p.Active().Colors
+}
+
+// AddSaved adds a color picked by the user to the active palette
+func (p *Palette) AddSaved(hex string) error {
+	return p.AddSavedColor(NewSavedColor(hex, SourcePicker))
+}
+
+// AddSavedColor adds an entry with its metadata to the active palette.
+// It returns ErrAlreadySaved for duplicates and ErrPaletteFull when the
+// limit is reached; nothing is ever evicted to make room.
+func (p *Palette) AddSavedColor(saved *SavedColor) error {
+	active := p.Active()
+
 	//Checking for existence
-	for _, color := range p.SavedColors {
-		if color == hex {
-			return false //Already saved
-		}
+	if active.Find(saved.Hex) != nil {
+		return ErrAlreadySaved
+	}
+
+	//Limit
+	if p.IsFull() {
+		return ErrPaletteFull
 	}
 
 	//Add new color
-	p.SavedColors = append(p.SavedColors, hex)
+	active.Colors = append(active.Colors, saved)
+	return nil
+}
 
-	//Limit
-	if len(p.SavedColors) > p.MaxSaved {
-		p.SavedColors = p.SavedColors[1:] //Remove oldest
+// IsFull reports whether the active palette has reached MaxSaved
+func (p *Palette) IsFull() bool {
+	return p.MaxSaved != Unlimited && len(p.Active().Colors) >= p.MaxSaved
+}
+
+// OldestToMakeRoom returns the saved colors that must go, oldest first by
+// creation time, before one more fits in the active palette. It is empty
+// when the palette has room.
+func (p *Palette) OldestToMakeRoom() []*SavedColor {
+	if !p.IsFull() {
+		return nil
 	}
 
-	return true
+	oldest := append([]*SavedColor(nil), p.Active().Colors...)
+	sort.SliceStable(oldest, func(i, j int) bool {
+		return oldest[i].Created.Before(oldest[j].Created)
+	})
+	return oldest[:len(oldest)-p.MaxSaved+1]
 }
 
-// RemoveSaved removes a color from saved colors
+// SetLimits changes how many recent and saved colors are kept. Recent colors
+// beyond the new limit are dropped; saved colors are kept, but no more can be
+// added until the palette is below the limit again.
+func (p *Palette) SetLimits(maxRecent, maxSaved int) error {
+	if maxRecent < 1 || maxSaved < 0 {
+		return ErrInvalidLimit
+	}
+
+	p.MaxRecent = maxRecent
+	p.MaxSaved = maxSaved
+	if len(p.RecentColors) > p.MaxRecent {
+		p.RecentColors = p.RecentColors[:p.MaxRecent]
+	}
+	return nil
+}
+
+// RemoveSaved removes a color from the active palette
 func (p *Palette) RemoveSaved(hex string) {
-	for i, color := range p.SavedColors {
-		if color == hex {
-			p.SavedColors = append(p.SavedColors[:i], p.SavedColors[i+1:]...)
+	active := p.Active()
+	for i, saved := range active.Colors {
+		if saved.Hex == hex {
+			active.Colors = append(active.Colors[:i], active.Colors[i+1:]...)
 			break
 		}
 	}
 }
 
-// Save palette to file
-func (p *Palette) Save() error {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
+// EditSaved updates the metadata of a color in the active palette
+func (p *Palette) EditSaved(hex, name string, tags []string, notes string) error {
+	saved := p.Active().Find(hex)
+	if saved == nil {
+		return ErrColorNotSaved
+	}
+	saved.Edit(name, tags, notes)
+	return nil
+}
+
+// Names returns the palette names in order
+func (p *Palette) Names() []string {
+	names := make([]string, len(p.Palettes))
+	for i, named := range p.Palettes {
+		names[i] = named.Name
+	}
+	return names
+}
+
+// Find returns the palette with the given name, or nil
+func (p *Palette) Find(name string) *NamedPalette {
+	for _, named := range p.Palettes {
+		if named.Name == name {
+			return named
+		}
+	}
+	return nil
+}
+
+// CreatePalette adds an empty palette and makes it active
+func (p *Palette) CreatePalette(name string) error {
+	name = strings.TrimSpace(name)
+	if err := p.checkNewName(name); err != nil {
 		return err
 	}
 
-	configDir := filepath.Join(homeDir, ".ladle-color-picker")
-	if err := os.MkdirAll(configDir, 0755); err != nil {
+	p.Palettes = append(p.Palettes, &NamedPalette{Name: name, Colors: make([]*SavedColor, 0)})
+	p.ActiveName = name
+	return nil
+}
+
+// AddPalette adds a complete palette, such as an imported one, and makes it
+// active. A taken name gets a number appended. The palette is kept whole even
+// when it holds more than MaxSaved colors, like after lowering the limit.
+func (p *Palette) AddPalette(named *NamedPalette) error {
+	name := strings.TrimSpace(named.Name)
+	if name == "" {
+		name = DefaultPaletteName
+	}
+	unique := name
+	for i := 2; p.Find(unique) != nil; i++ {
+		unique = fmt.Sprintf("%s %d", name, i)
+	}
+
+	named.Name = unique
+	p.Palettes = append(p.Palettes, named)
+	p.ActiveName = unique
+	return nil
+}
+
+// RenamePalette changes a palette's name
+func (p *Palette) RenamePalette(oldName, newName string) error {
+	named := p.Find(oldName)
+	if named == nil {
+		return ErrPaletteNotFound
+	}
+
+	newName = strings.TrimSpace(newName)
+	if newName == oldName {
+		return nil
+	}
+	if err := p.checkNewName(newName); err != nil {
 		return err
 	}
 
-	file := filepath.Join(configDir, "palette.json")
-	data, err := json.MarshalIndent(p, "", " ")
+	named.Name = newName
+	if p.ActiveName == oldName {
+		p.ActiveName = newName
+	}
+	return nil
+}
+
+// DuplicatePalette copies a palette under a new name and makes the copy active
+func (p *Palette) DuplicatePalette(name, newName string) error {
+	named := p.Find(name)
+	if named == nil {
+		return ErrPaletteNotFound
+	}
+
+	newName = strings.TrimSpace(newName)
+	if err := p.checkNewName(newName); err != nil {
+		return err
+	}
+
+	p.Palettes = append(p.Palettes, named.clone(newName))
+	p.ActiveName = newName
+	return nil
+}
+
+// DeletePalette removes a palette. The last remaining palette cannot be deleted.
+func (p *Palette) DeletePalette(name string) error {
+	if len(p.Palettes) <= 1 {
+		return ErrLastPalette
+	}
+
+	for i, named := range p.Palettes {
+		if named.Name == name {
+			p.Palettes = append(p.Palettes[:i], p.Palettes[i+1:]...)
+			if p.ActiveName == name {
+				p.ActiveName = p.Palettes[0].Name
+			}
+			return nil
+		}
+	}
+	return ErrPaletteNotFound
+}
+
+// SwitchPalette makes another palette active
+func (p *Palette) SwitchPalette(name string) error {
+	if p.Find(name) == nil {
+		return ErrPaletteNotFound
+	}
+	p.ActiveName = name
+	return nil
+}
+
+// PaletteSnapshot is a deep copy of the named palettes, used to undo edits
+type PaletteSnapshot struct {
+	palettes []*NamedPalette
+	active   string
+}
+
+// Snapshot copies the named palettes and the active selection
+func (p *Palette) Snapshot() *PaletteSnapshot {
+	snap := &PaletteSnapshot{active: p.ActiveName}
+	for _, named := range p.Palettes {
+		snap.palettes = append(snap.palettes, named.clone(named.Name))
+	}
+	return snap
+}
+
+// Restore puts the named palettes back to a snapshot
+func (p *Palette) Restore(snap *PaletteSnapshot) {
+	p.Palettes = nil
+	for _, named := range snap.palettes {
+		p.Palettes = append(p.Palettes, named.clone(named.Name))
+	}
+	p.ActiveName = snap.active
+}
+
+func (p *Palette) checkNewName(name string) error {
+	if name == "" {
+		return ErrPaletteName
+	}
+	if p.Find(name) != nil {
+		return ErrPaletteExists
+	}
+	return nil
+}
+
+// Save palette to file
+func (p *Palette) Save() error {
+	data, err := p.Encode()
 	if err != nil {
 		return err
 	}
+	return WritePaletteFile(data)
+}
 
+// Encode returns the palette file contents
+func (p *Palette) Encode() ([]byte, error) {
+	return json.MarshalIndent(p, "", " ")
+}
+
+// WritePaletteFile writes encoded palette data to the config directory
+func WritePaletteFile(data []byte) error {
+	file, err := configFile(paletteFileName)
+	if err != nil {
+		return err
+	}
 	return os.WriteFile(file, data, 0644)
 }
 
 // Load palette from file
 func (p *Palette) Load() error {
-	homeDir, err := os.UserHomeDir()
+	file, err := configFile(paletteFileName)
 	if err != nil {
 		return err
 	}
 
-	file := filepath.Join(homeDir, ".ladle-color-picker", "palette.json")
 	data, err := os.ReadFile(file)
 	if err != nil {
 		return err //File doesn't exist yet
 	}
 
-	return json.Unmarshal(data, p)
+	return p.Decode(data)
+}
+
+// Decode reads palette file contents, sanitizing settings and migrating older layouts
+func (p *Palette) Decode(data []byte) error {
+	if err := json.Unmarshal(data, p); err != nil {
+		return err
+	}
+	if p.MaxRecent < 1 || p.MaxSaved < 0 {
+		p.MaxRecent, p.MaxSaved = defaultMaxRecent, defaultMaxSaved
+	}
+	if p.DuplicateThreshold < 0 {
+		p.DuplicateThreshold = DefaultDuplicateThreshold
+	}
+
+	return p.migrate(data)
+}
+
+// migrate moves the single saved_colors list of older files into the default palette.
+// Plain hex strings are turned into entries by SavedColor.UnmarshalJSON.
+func (p *Palette) migrate(data []byte) error {
+	var legacy struct {
+		SavedColors []*SavedColor   `json:"saved_colors"`
+		Palettes    json.RawMessage `json:"palettes"`
+	}
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return err
+	}
+
+	if legacy.SavedColors != nil && legacy.Palettes == nil {
+		p.Palettes = []*NamedPalette{{Name: DefaultPaletteName, Colors: legacy.SavedColors}}
+		p.ActiveName = DefaultPaletteName
+	}
+	return nil
+}
+
+// configFile returns the path of a file in the config directory, creating the directory if needed
+func configFile(name string) (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	configDir := filepath.Join(homeDir, configDirName)
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, name), nil
 }
@@ -0,0 +1,55 @@
+package color
+
+import "math"
+
+// Ramp returns shades of c at the given OKLCH lightness values (0..1),
+// keeping its hue. Chroma tapers towards white and black and is reduced
+// further where needed to stay inside the sRGB gamut, so the hue does not
+// shift the way clamping channels would. The shade closest in lightness to c
+// is replaced by c itself, so the original color always appears in the ramp.
+func Ramp(c *Color, lightness []float64) []*Color {
+	base := c.To(OKLCH)
+
+	shades := make([]*Color, len(lightness))
+	closest := -1
+	for i, l := range lightness {
+		//Fall off as the lightness approaches either end
+		taper := math.Min(1, 4*l*(1-l)/(4*base[0]*(1-base[0])+1e-9))
+		shades[i] = inSRGBGamut(Values{l, base[1] * taper, base[2]})
+
+		if closest < 0 || math.Abs(l-base[0]) < math.Abs(lightness[closest]-base[0]) {
+			closest = i
+		}
+	}
+	if closest >= 0 {
+		shades[closest] = NewColor(c.R, c.G, c.B)
+	}
+	return shades
+}
+
+// inSRGBGamut lowers the chroma of an OKLCH color until it fits in sRGB
+func inSRGBGamut(v Values) *Color {
+	fits := func(chroma float64) bool {
+		rgb := Convert(Values{v[0], chroma, v[2]}, OKLCH, SRGB)
+		for _, ch := range rgb {
+			if ch < -gamutEpsilon || ch > 1+gamutEpsilon {
+				return false
+			}
+		}
+		return true
+	}
+
+	if !fits(v[1]) {
+		low, high := 0.0, v[1]
+		for i := 0; i < 20; i++ {
+			mid := (low + high) / 2
+			if fits(mid) {
+				low = mid
+			} else {
+				high = mid
+			}
+		}
+		v[1] = low
+	}
+	return FromSpace(v, OKLCH)
+}
@@ -0,0 +1,368 @@
+package color
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// RGBSpace describes an RGB color space by its primaries, white point and transfer curve
+type RGBSpace struct {
+	name    string
+	cssName string
+
+	toXYZ   [3][3]float64 // linear RGB -> XYZ (D65)
+	fromXYZ [3][3]float64 // XYZ (D65) -> linear RGB
+	decode  func(float64) float64
+	encode  func(float64) float64
+}
+
+// White points as xy chromaticities
+var (
+	whiteD65 = [2]float64{0.3127, 0.3290}
+	whiteD50 = [2]float64{0.3457, 0.3585}
+)
+
+// Supported RGB color spaces
+var (
+	SRGB = newRGBSpace("sRGB", "srgb",
+		[3][2]float64{{0.640, 0.330}, {0.300, 0.600}, {0.150, 0.060}}, whiteD65,
+		srgbDecode, srgbEncode)
+
+	DisplayP3 = newRGBSpace("Display P3", "display-p3",
+		[3][2]float64{{0.680, 0.320}, {0.265, 0.690}, {0.150, 0.060}}, whiteD65,
+		srgbDecode, srgbEncode)
+
+	Rec2020 = newRGBSpace("Rec. 2020", "rec2020",
+		[3][2]float64{{0.708, 0.292}, {0.170, 0.797}, {0.131, 0.046}}, whiteD65,
+		rec2020Decode, rec2020Encode)
+
+	AdobeRGB = newRGBSpace("Adobe RGB", "a98-rgb",
+		[3][2]float64{{0.640, 0.330}, {0.210, 0.710}, {0.150, 0.060}}, whiteD65,
+		gammaDecode(563.0/256.0), gammaEncode(563.0/256.0))
+
+	ProPhotoRGB = newRGBSpace("ProPhoto RGB", "prophoto-rgb",
+		[3][2]float64{{0.734699, 0.265301}, {0.159597, 0.840403}, {0.036598, 0.000105}}, whiteD50,
+		prophotoDecode, prophotoEncode)
+)
+
+// RGBSpaces lists the RGB spaces in order of increasing gamut
+var RGBSpaces = []*RGBSpace{SRGB, DisplayP3, AdobeRGB, Rec2020, ProPhotoRGB}
+
+// newRGBSpace derives the XYZ matrices from the primaries and white point.
+// Spaces with a white point other than D65 are Bradford-adapted to D65.
+func newRGBSpace(name, cssName string, primaries [3][2]float64, white [2]float64, decode, encode func(float64) float64) *RGBSpace {
+	var p [3][3]float64
+	for i, xy := range primaries {
+		px := xyToXYZ(xy)
+		p[0][i], p[1][i], p[2][i] = px[0], px[1], px[2]
+	}
+
+	s := mulVec(invert(p), xyToXYZ(white))
+	var m [3][3]float64
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 3; col++ {
+			m[row][col] = p[row][col] * s[col]
+		}
+	}
+
+	if white != whiteD65 {
+		m = mulMat(bradford(white, whiteD65), m)
+	}
+
+	return &RGBSpace{
+		name:    name,
+		cssName: cssName,
+		toXYZ:   m,
+		fromXYZ: invert(m),
+		decode:  decode,
+		encode:  encode,
+	}
+}
+
+// Name returns the display name of the space
+func (s *RGBSpace) Name() string {
+	return s.name
+}
+
+// CSSName returns the identifier used in CSS color()
+func (s *RGBSpace) CSSName() string {
+	return s.cssName
+}
+
+// ToXYZ converts encoded RGB channels to XYZ (D65)
+func (s *RGBSpace) ToXYZ(v Values) Values {
+	return mulVec(s.toXYZ, Values{s.decode(v[0]), s.decode(v[1]), s.decode(v[2])})
+}
+
+// FromXYZ converts XYZ (D65) to encoded RGB channels
+func (s *RGBSpace) FromXYZ(xyz Values) Values {
+	lin := mulVec(s.fromXYZ, xyz)
+	return Values{s.encode(lin[0]), s.encode(lin[1]), s.encode(lin[2])}
+}
+
+// Format returns the channels as CSS color()
+func (s *RGBSpace) Format(v Values) string {
+	return NewWideColor(s, v[0], v[1], v[2]).ToCSS()
+}
+
+// RGBSpaceByCSSName looks up a space by its CSS color() identifier
+func RGBSpaceByCSSName(name string) (*RGBSpace, bool) {
+	for _, s := range RGBSpaces {
+		if s.cssName == strings.ToLower(name) {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// WideColor is a color in an arbitrary RGB space with unclamped channels in 0..1
+type WideColor struct {
+	Space   *RGBSpace
+	R, G, B float64
+	A       float64
+}
+
+// NewWideColor creates an opaque color in the given space
+func NewWideColor(space *RGBSpace, r, g, b float64) *WideColor {
+	return &WideColor{Space: space, R: r, G: g, B: b, A: 1}
+}
+
+// In converts the color to another RGB space
+func (c *Color) In(space *RGBSpace) *WideColor {
+	return NewWideColor(SRGB, float64(c.R)/255, float64(c.G)/255, float64(c.B)/255).Convert(space)
+}
+
+// Convert returns the same color expressed in another RGB space
+func (w *WideColor) Convert(space *RGBSpace) *WideColor {
+	if w.Space == space {
+		out := *w
+		return &out
+	}
+
+	v := space.FromXYZ(w.Space.ToXYZ(Values{w.R, w.G, w.B}))
+	return &WideColor{Space: space, R: v[0], G: v[1], B: v[2], A: w.A}
+}
+
+// gamutEpsilon absorbs rounding noise from the matrix round trip
+const gamutEpsilon = 1e-4
+
+// InGamut reports whether all channels lie within 0..1 in the color's own space
+func (w *WideColor) InGamut() bool {
+	for _, v := range (Values{w.R, w.G, w.B}) {
+		if v < -gamutEpsilon || v > 1+gamutEpsilon {
+			return false
+		}
+	}
+	return true
+}
+
+// InSRGBGamut reports whether the color can be shown exactly on an sRGB display
+func (w *WideColor) InSRGBGamut() bool {
+	return w.Convert(SRGB).InGamut()
+}
+
+// ToColor converts to an 8-bit sRGB color, clamping out of gamut channels
+func (w *WideColor) ToColor() *Color {
+	s := w.Convert(SRGB)
+	return NewColor(clampByte(s.R), clampByte(s.G), clampByte(s.B))
+}
+
+// ToCSS returns a CSS color() string like "color(display-p3 1 0.5 0)"
+func (w *WideColor) ToCSS() string {
+	css := fmt.Sprintf("color(%s %s %s %s", w.Space.cssName,
+		formatChannel(w.R), formatChannel(w.G), formatChannel(w.B))
+	if w.A < 1 {
+		css += " / " + formatChannel(w.A)
+	}
+	return css + ")"
+}
+
+// ParseCSSColor parses a CSS color() string such as "color(display-p3 1 0.5 0 / 0.8)"
+func ParseCSSColor(css string) (*WideColor, error) {
+	s := strings.TrimSpace(css)
+	if !strings.HasPrefix(strings.ToLower(s), "color(") || !strings.HasSuffix(s, ")") {
+		return nil, fmt.Errorf("invalid color() format: %s", css)
+	}
+	s = s[len("color(") : len(s)-1]
+
+	alpha := "1"
+	if i := strings.Index(s, "/"); i >= 0 {
+		alpha = strings.TrimSpace(s[i+1:])
+		s = s[:i]
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("color() needs a space and three channels: %s", css)
+	}
+
+	space, ok := RGBSpaceByCSSName(fields[0])
+	if !ok {
+		return nil, fmt.Errorf("unsupported color space: %s", fields[0])
+	}
+
+	var ch [4]float64
+	for i, f := range append(fields[1:], alpha) {
+		v, err := parseChannel(f)
+		if err != nil {
+			return nil, err
+		}
+		ch[i] = v
+	}
+
+	return &WideColor{Space: space, R: ch[0], G: ch[1], B: ch[2], A: math.Max(0, math.Min(1, ch[3]))}, nil
+}
+
+// parseChannel accepts plain numbers and percentages
+func parseChannel(s string) (float64, error) {
+	if strings.HasSuffix(s, "%") {
+		v, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+		return v / 100, err
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// formatChannel prints up to four decimals without trailing zeros
+func formatChannel(v float64) string {
+	return formatNumber(v, 4)
+}
+
+func clampByte(v float64) uint8 {
+	return uint8(math.Round(math.Max(0, math.Min(1, v)) * 255))
+}
+
+// Transfer functions, mirrored around zero so out of gamut values survive the round trip
+
+func srgbDecode(v float64) float64 {
+	a := math.Abs(v)
+	if a <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Copysign(math.Pow((a+0.055)/1.055, 2.4), v)
+}
+
+func srgbEncode(v float64) float64 {
+	a := math.Abs(v)
+	if a <= 0.0031308 {
+		return v * 12.92
+	}
+	return math.Copysign(1.055*math.Pow(a, 1/2.4)-0.055, v)
+}
+
+const (
+	rec2020Alpha = 1.09929682680944
+	rec2020Beta  = 0.018053968510807
+)
+
+func rec2020Decode(v float64) float64 {
+	a := math.Abs(v)
+	if a < rec2020Beta*4.5 {
+		return v / 4.5
+	}
+	return math.Copysign(math.Pow((a+rec2020Alpha-1)/rec2020Alpha, 1/0.45), v)
+}
+
+func rec2020Encode(v float64) float64 {
+	a := math.Abs(v)
+	if a < rec2020Beta {
+		return v * 4.5
+	}
+	return math.Copysign(rec2020Alpha*math.Pow(a, 0.45)-(rec2020Alpha-1), v)
+}
+
+func gammaDecode(gamma float64) func(float64) float64 {
+	return func(v float64) float64 {
+		return math.Copysign(math.Pow(math.Abs(v), gamma), v)
+	}
+}
+
+func gammaEncode(gamma float64) func(float64) float64 {
+	return func(v float64) float64 {
+		return math.Copysign(math.Pow(math.Abs(v), 1/gamma), v)
+	}
+}
+
+func prophotoDecode(v float64) float64 {
+	a := math.Abs(v)
+	if a <= 16.0/512 {
+		return v / 16
+	}
+	return math.Copysign(math.Pow(a, 1.8), v)
+}
+
+func prophotoEncode(v float64) float64 {
+	a := math.Abs(v)
+	if a < 1.0/512 {
+		return v * 16
+	}
+	return math.Copysign(math.Pow(a, 1/1.8), v)
+}
+
+// Matrix helpers
+
+func xyToXYZ(xy [2]float64) [3]float64 {
+	return [3]float64{xy[0] / xy[1], 1, (1 - xy[0] - xy[1]) / xy[1]}
+}
+
+// bradford returns the chromatic adaptation matrix between two white points
+func bradford(from, to [2]float64) [3][3]float64 {
+	m := [3][3]float64{
+		{0.8951, 0.2664, -0.1614},
+		{-0.7502, 1.7135, 0.0367},
+		{0.0389, -0.0685, 1.0296},
+	}
+	src := mulVec(m, xyToXYZ(from))
+	dst := mulVec(m, xyToXYZ(to))
+	scale := [3][3]float64{
+		{dst[0] / src[0], 0, 0},
+		{0, dst[1] / src[1], 0},
+		{0, 0, dst[2] / src[2]},
+	}
+	return mulMat(invert(m), mulMat(scale, m))
+}
+
+func mulVec(m [3][3]float64, v [3]float64) [3]float64 {
+	return [3]float64{
+		m[0][0]*v[0] + m[0][1]*v[1] + m[0][2]*v[2],
+		m[1][0]*v[0] + m[1][1]*v[1] + m[1][2]*v[2],
+		m[2][0]*v[0] + m[2][1]*v[1] + m[2][2]*v[2],
+	}
+}
+
+func mulMat(a, b [3][3]float64) [3][3]float64 {
+	var out [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				out[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+	return out
+}
+
+func invert(m [3][3]float64) [3][3]float64 {
+	det := m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+
+	return [3][3]float64{
+		{
+			(m[1][1]*m[2][2] - m[1][2]*m[2][1]) / det,
+			(m[0][2]*m[2][1] - m[0][1]*m[2][2]) / det,
+			(m[0][1]*m[1][2] - m[0][2]*m[1][1]) / det,
+		},
+		{
+			(m[1][2]*m[2][0] - m[1][0]*m[2][2]) / det,
+			(m[0][0]*m[2][2] - m[0][2]*m[2][0]) / det,
+			(m[0][2]*m[1][0] - m[0][0]*m[1][2]) / det,
+		},
+		{
+			(m[1][0]*m[2][1] - m[1][1]*m[2][0]) / det,
+			(m[0][1]*m[2][0] - m[0][0]*m[2][1]) / det,
+			(m[0][0]*m[1][1] - m[0][1]*m[1][0]) / det,
+		},
+	}
+}
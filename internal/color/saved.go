@@ -0,0 +1,92 @@
+package color
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// Where a saved color came from
+const (
+	SourcePicker   = "picker"
+	SourceImport   = "import"
+	SourceMigrated = "migrated"
+)
+
+// SavedColor is a color in a named palette together with its metadata
+type SavedColor struct {
+	Hex     string    `json:"hex"`
+	Name    string    `json:"name,omitempty"`
+	Tags    []string  `json:"tags,omitempty"`
+	Notes   string    `json:"notes,omitempty"`
+	Created time.Time `json:"created"`
+	Updated time.Time `json:"updated"`
+	Source  string    `json:"source,omitempty"`
+
+	// CMYK keeps print values (0..1) from imported files so they survive export
+	CMYK []float64 `json:"cmyk,omitempty"`
+
+	// Group is the swatch group from an imported file, written back on export
+	Group string `json:"group,omitempty"`
+}
+
+// NewSavedColor creates an entry stamped with the current time
+func NewSavedColor(hex, source string) *SavedColor {
+	now := time.Now()
+	return &SavedColor{Hex: hex, Created: now, Updated: now, Source: source}
+}
+
+// Label returns the name if there is one, otherwise the hex code
+func (s *SavedColor) Label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Hex
+}
+
+// Edit replaces the editable metadata and bumps the updated time
+func (s *SavedColor) Edit(name string, tags []string, notes string) {
+	s.Name = strings.TrimSpace(name)
+	s.Tags = tags
+	s.Notes = notes
+	s.Updated = time.Now()
+}
+
+// HasTag reports whether the entry carries the tag, ignoring case
+func (s *SavedColor) HasTag(tag string) bool {
+	for _, t := range s.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON also accepts a bare hex string, which is how
+// palette files stored saved colors before metadata was added
+func (s *SavedColor) UnmarshalJSON(data []byte) error {
+	var hex string
+	if err := json.Unmarshal(data, &hex); err == nil {
+		now := time.Now()
+		*s = SavedColor{Hex: hex, Created: now, Updated: now, Source: SourceMigrated}
+		return nil
+	}
+
+	type plain SavedColor
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// ParseTags splits a comma separated list, dropping blanks and duplicates
+func ParseTags(text string) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, t := range strings.Split(text, ",") {
+		t = strings.TrimSpace(t)
+		if t == "" || seen[strings.ToLower(t)] {
+			continue
+		}
+		seen[strings.ToLower(t)] = true
+		tags = append(tags, t)
+	}
+	return tags
+}
@@ -0,0 +1,177 @@
+package color
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// SortKey selects how saved colors are ordered
+type SortKey int
+
+const (
+	SortByHue SortKey = iota
+	SortByLightness
+	SortByChroma
+	SortByName
+	SortPerceptual //smooth transitions between neighbours
+)
+
+// SortKeys lists every sort key in menu order
+var SortKeys = []SortKey{SortByHue, SortByLightness, SortByChroma, SortByName, SortPerceptual}
+
+// String returns the menu label of the key
+func (k SortKey) String() string {
+	switch k {
+	case SortByHue:
+		return "Hue"
+	case SortByLightness:
+		return "Lightness"
+	case SortByChroma:
+		return "Chroma"
+	case SortByName:
+		return "Name"
+	default:
+		return "Smooth (perceptual)"
+	}
+}
+
+// SortSaved reorders the colors of the active palette once
+func (p *Palette) SortSaved(key SortKey) {
+	active := p.Active()
+	active.Colors = SortColors(active.Colors, key)
+}
+
+// MoveSaved moves a color of the active palette from one position to another.
+// The target is clamped to the ends of the palette. It reports whether the
+// order changed; moving a color onto its own position does nothing.
+func (p *Palette) MoveSaved(from, to int) (bool, error) {
+	colors := p.Active().Colors
+	if from < 0 || from >= len(colors) {
+		return false, ErrColorNotSaved
+	}
+	if to < 0 {
+		to = 0
+	}
+	if to >= len(colors) {
+		to = len(colors) - 1
+	}
+	if from == to {
+		return false, nil
+	}
+
+	moved := colors[from]
+	copy(colors[from:], colors[from+1:])
+	colors = colors[:len(colors)-1]
+	colors = append(colors[:to], append([]*SavedColor{moved}, colors[to:]...)...)
+	p.Active().Colors = colors
+	return true, nil
+}
+
+// SortColors returns the colors in a new order; the input is not modified.
+// Hue, lightness and chroma come from OKLCH.
+func SortColors(colors []*SavedColor, key SortKey) []*SavedColor {
+	out := append([]*SavedColor(nil), colors...)
+	lch := make(map[*SavedColor]Values, len(out))
+	for _, s := range out {
+		if col, err := NewColorHex(s.Hex); err == nil {
+			lch[s] = col.To(OKLCH)
+		}
+	}
+
+	switch key {
+	case SortByHue:
+		sort.SliceStable(out, func(i, j int) bool {
+			a, b := lch[out[i]], lch[out[j]]
+			//Grays have no hue, keep them together at the end by lightness
+			if grayA, grayB := a[1] < achromaticChroma, b[1] < achromaticChroma; grayA != grayB {
+				return grayB
+			} else if grayA {
+				return a[0] < b[0]
+			}
+			return a[2] < b[2]
+		})
+	case SortByLightness:
+		sort.SliceStable(out, func(i, j int) bool { return lch[out[i]][0] < lch[out[j]][0] })
+	case SortByChroma:
+		sort.SliceStable(out, func(i, j int) bool { return lch[out[i]][1] > lch[out[j]][1] })
+	case SortByName:
+		sort.SliceStable(out, func(i, j int) bool {
+			return strings.ToLower(out[i].Label()) < strings.ToLower(out[j].Label())
+		})
+	case SortPerceptual:
+		out = perceptualOrder(out)
+	}
+
+	return out
+}
+
+// achromaticChroma is the OKLCH chroma below which a color counts as gray
+const achromaticChroma = 0.02
+
+// perceptualOrder approximates the shortest path through all colors in OKLab,
+// a travelling salesman tour without the return leg: it starts at the darkest
+// color, walks to the nearest unvisited one, then improves the path with 2-opt.
+func perceptualOrder(colors []*SavedColor) []*SavedColor {
+	n := len(colors)
+	if n < 3 {
+		return colors
+	}
+
+	lab := make([]Values, n)
+	start := 0
+	for i, s := range colors {
+		if col, err := NewColorHex(s.Hex); err == nil {
+			lab[i] = col.To(OKLab)
+		}
+		if lab[i][0] < lab[start][0] {
+			start = i
+		}
+	}
+	dist := func(i, j int) float64 {
+		a, b := lab[i], lab[j]
+		return math.Sqrt((a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2]))
+	}
+
+	//Nearest neighbour
+	path := []int{start}
+	visited := make([]bool, n)
+	visited[start] = true
+	for len(path) < n {
+		last, next := path[len(path)-1], -1
+		for j := 0; j < n; j++ {
+			if !visited[j] && (next < 0 || dist(last, j) < dist(last, next)) {
+				next = j
+			}
+		}
+		visited[next] = true
+		path = append(path, next)
+	}
+
+	//2-opt: reverse segments while that shortens the path
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				before := dist(path[i], path[i+1])
+				after := dist(path[i], path[j])
+				if j+1 < n {
+					before += dist(path[j], path[j+1])
+					after += dist(path[i+1], path[j+1])
+				}
+				if after < before-1e-9 {
+					for a, b := i+1, j; a < b; a, b = a+1, b-1 {
+						path[a], path[b] = path[b], path[a]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+
+	out := make([]*SavedColor, n)
+	for i, idx := range path {
+		out[i] = colors[idx]
+	}
+	return out
+}
@@ -0,0 +1,389 @@
+package color
+
+import (
+	"fmt"
+	"math"
+)
+
+// Values holds the three components of a color in some color space
+type Values [3]float64
+
+// ColorSpace is a color model that can convert to and from the XYZ (D65) hub,
+// which is enough to convert between any two registered spaces
+type ColorSpace interface {
+	Name() string
+	ToXYZ(v Values) Values
+	FromXYZ(xyz Values) Values
+	Format(v Values) string
+}
+
+// srgbModel is implemented by spaces that are a reshaping of sRGB (HSL, HSV).
+// Convert uses it to skip the XYZ hub and the rounding drift it brings.
+type srgbModel interface {
+	fromSRGB(rgb Values) Values
+	toSRGB(v Values) Values
+}
+
+var registry []ColorSpace
+
+// Register adds a color space to the registry. Registering a space with
+// an existing name replaces it.
+func Register(space ColorSpace) {
+	for i, s := range registry {
+		if s.Name() == space.Name() {
+			registry[i] = space
+			return
+		}
+	}
+	registry = append(registry, space)
+}
+
+// Spaces returns all registered color spaces in registration order
+func Spaces() []ColorSpace {
+	return append([]ColorSpace(nil), registry...)
+}
+
+// SpaceByName looks up a registered color space
+func SpaceByName(name string) (ColorSpace, bool) {
+	for _, s := range registry {
+		if s.Name() == name {
+			return s, true
+		}
+	}
+	return nil, false
+}
+
+// Convert converts values from one color space to another
+func Convert(v Values, from, to ColorSpace) Values {
+	if from == to {
+		return v
+	}
+
+	if rgb, ok := asSRGB(v, from); ok {
+		if to == ColorSpace(SRGB) {
+			return rgb
+		}
+		if m, ok := to.(srgbModel); ok {
+			return m.fromSRGB(rgb)
+		}
+	}
+
+	return to.FromXYZ(from.ToXYZ(v))
+}
+
+// asSRGB returns sRGB channels directly when the source space allows it
+func asSRGB(v Values, from ColorSpace) (Values, bool) {
+	if from == ColorSpace(SRGB) {
+		return v, true
+	}
+	if m, ok := from.(srgbModel); ok {
+		return m.toSRGB(v), true
+	}
+	return Values{}, false
+}
+
+// To returns the color's components in the given space
+func (c *Color) To(space ColorSpace) Values {
+	rgb := Values{float64(c.R) / 255, float64(c.G) / 255, float64(c.B) / 255}
+	return Convert(rgb, SRGB, space)
+}
+
+// Format returns the color formatted in the given space
+func (c *Color) Format(space ColorSpace) string {
+	return space.Format(c.To(space))
+}
+
+// FromSpace creates an sRGB color from components in any space, clamping out of gamut channels
+func FromSpace(v Values, space ColorSpace) *Color {
+	rgb := Convert(v, space, SRGB)
+	return NewColor(clampByte(rgb[0]), clampByte(rgb[1]), clampByte(rgb[2]))
+}
+
+// Non-RGB spaces
+var (
+	HSL   ColorSpace = hslSpace{}
+	HSV   ColorSpace = hsvSpace{}
+	XYZ   ColorSpace = xyzSpace{}
+	Lab   ColorSpace = labSpace{}
+	LCH   ColorSpace = lchSpace{}
+	OKLab ColorSpace = oklabSpace{}
+	OKLCH ColorSpace = oklchSpace{}
+)
+
+func init() {
+	Register(SRGB)
+	Register(HSL)
+	Register(HSV)
+	for _, s := range RGBSpaces[1:] {
+		Register(s)
+	}
+	Register(XYZ)
+	Register(Lab)
+	Register(LCH)
+	Register(OKLab)
+	Register(OKLCH)
+}
+
+// hslSpace is hue (degrees), saturation and lightness (0..1) over sRGB
+type hslSpace struct{}
+
+func (hslSpace) Name() string              { return "HSL" }
+func (s hslSpace) ToXYZ(v Values) Values   { return SRGB.ToXYZ(s.toSRGB(v)) }
+func (s hslSpace) FromXYZ(x Values) Values { return s.fromSRGB(SRGB.FromXYZ(x)) }
+
+func (hslSpace) Format(v Values) string {
+	return fmt.Sprintf("hsl(%.0f, %.0f%%, %.0f%%)", v[0], v[1]*100, v[2]*100)
+}
+
+func (hslSpace) fromSRGB(rgb Values) Values {
+	max, min, h := hueOf(rgb)
+	l := (max + min) / 2
+
+	var s float64
+	if max != min {
+		d := max - min
+		if l > 0.5 {
+			s = d / (2 - max - min)
+		} else {
+			s = d / (max + min)
+		}
+	}
+
+	return Values{h, s, l}
+}
+
+func (hslSpace) toSRGB(v Values) Values {
+	h, s, l := v[0], v[1], v[2]
+	c := (1 - math.Abs(2*l-1)) * s
+	return hueToRGB(h, c, l-c/2)
+}
+
+// hsvSpace is hue (degrees), saturation and value (0..1) over sRGB
+type hsvSpace struct{}
+
+func (hsvSpace) Name() string              { return "HSV" }
+func (s hsvSpace) ToXYZ(v Values) Values   { return SRGB.ToXYZ(s.toSRGB(v)) }
+func (s hsvSpace) FromXYZ(x Values) Values { return s.fromSRGB(SRGB.FromXYZ(x)) }
+
+func (hsvSpace) Format(v Values) string {
+	return fmt.Sprintf("hsv(%.0f, %.0f%%, %.0f%%)", v[0], v[1]*100, v[2]*100)
+}
+
+func (hsvSpace) fromSRGB(rgb Values) Values {
+	max, min, h := hueOf(rgb)
+
+	var s float64
+	if max != 0 {
+		s = (max - min) / max
+	}
+
+	return Values{h, s, max}
+}
+
+func (hsvSpace) toSRGB(v Values) Values {
+	c := v[2] * v[1]
+	return hueToRGB(v[0], c, v[2]-c)
+}
+
+// hueOf returns the largest and smallest channel and the hue in degrees
+func hueOf(rgb Values) (float64, float64, float64) {
+	r, g, b := rgb[0], rgb[1], rgb[2]
+	max := math.Max(math.Max(r, g), b)
+	min := math.Min(math.Min(r, g), b)
+	if max == min {
+		return max, min, 0 //achromatic
+	}
+
+	d := max - min
+	var h float64
+	switch max {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	case b:
+		h = (r-g)/d + 4
+	}
+
+	return max, min, h * 60
+}
+
+// hueToRGB builds RGB from hue, chroma and the amount added to every channel
+func hueToRGB(h, c, m float64) Values {
+	h = math.Mod(math.Mod(h, 360)+360, 360) / 60
+	x := c * (1 - math.Abs(math.Mod(h, 2)-1))
+
+	var r, g, b float64
+	switch {
+	case h < 1:
+		r, g = c, x
+	case h < 2:
+		r, g = x, c
+	case h < 3:
+		g, b = c, x
+	case h < 4:
+		g, b = x, c
+	case h < 5:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+
+	return Values{r + m, g + m, b + m}
+}
+
+// xyzSpace is the CIE XYZ hub itself, relative to D65
+type xyzSpace struct{}
+
+func (xyzSpace) Name() string            { return "XYZ D65" }
+func (xyzSpace) ToXYZ(v Values) Values   { return v }
+func (xyzSpace) FromXYZ(x Values) Values { return x }
+func (xyzSpace) Format(v Values) string  { return formatCSS("color(xyz-d65 ", v) }
+
+// labSpace is CIE Lab relative to D50, as used by CSS lab()
+type labSpace struct{}
+
+var (
+	adaptD65toD50 = bradford(whiteD65, whiteD50)
+	adaptD50toD65 = bradford(whiteD50, whiteD65)
+	labWhite      = xyToXYZ(whiteD50)
+)
+
+const (
+	labEpsilon = 216.0 / 24389.0
+	labKappa   = 24389.0 / 27.0
+)
+
+func (labSpace) Name() string { return "Lab" }
+
+func (labSpace) ToXYZ(v Values) Values {
+	fy := (v[0] + 16) / 116
+	fx := fy + v[1]/500
+	fz := fy - v[2]/200
+
+	finv := func(f float64) float64 {
+		if f*f*f > labEpsilon {
+			return f * f * f
+		}
+		return (116*f - 16) / labKappa
+	}
+
+	d50 := Values{finv(fx) * labWhite[0], finv(fy) * labWhite[1], finv(fz) * labWhite[2]}
+	return mulVec(adaptD50toD65, d50)
+}
+
+func (labSpace) FromXYZ(x Values) Values {
+	d50 := mulVec(adaptD65toD50, x)
+
+	f := func(t float64) float64 {
+		if t > labEpsilon {
+			return math.Cbrt(t)
+		}
+		return (labKappa*t + 16) / 116
+	}
+
+	fx, fy, fz := f(d50[0]/labWhite[0]), f(d50[1]/labWhite[1]), f(d50[2]/labWhite[2])
+	return Values{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+func (labSpace) Format(v Values) string {
+	return fmt.Sprintf("lab(%s%% %s %s)", formatNumber(v[0], 2), formatNumber(v[1], 2), formatNumber(v[2], 2))
+}
+
+// lchSpace is the cylindrical form of Lab
+type lchSpace struct{}
+
+func (lchSpace) Name() string            { return "LCH" }
+func (lchSpace) ToXYZ(v Values) Values   { return Lab.ToXYZ(fromPolar(v)) }
+func (lchSpace) FromXYZ(x Values) Values { return toPolar(Lab.FromXYZ(x)) }
+
+func (lchSpace) Format(v Values) string {
+	return fmt.Sprintf("lch(%s%% %s %s)", formatNumber(v[0], 2), formatNumber(v[1], 2), formatNumber(v[2], 2))
+}
+
+// oklabSpace is Björn Ottosson's perceptual OKLab
+type oklabSpace struct{}
+
+var (
+	oklabM1 = [3][3]float64{
+		{0.8190224379967030, 0.3619062600528904, -0.1288737815209879},
+		{0.0329836539323885, 0.9292868615863434, 0.0361446663506424},
+		{0.0481771893596242, 0.2642395317527308, 0.6335478284694309},
+	}
+	oklabM2 = [3][3]float64{
+		{0.2104542683093140, 0.7936177747023054, -0.0040720430116193},
+		{1.9779985324311684, -2.4285922420485799, 0.4505937096174110},
+		{0.0259040424655478, 0.7827717124575296, -0.8086757549230774},
+	}
+	oklabM1Inv = invert(oklabM1)
+	oklabM2Inv = invert(oklabM2)
+)
+
+func (oklabSpace) Name() string { return "OKLab" }
+
+func (oklabSpace) ToXYZ(v Values) Values {
+	lms := mulVec(oklabM2Inv, v)
+	return mulVec(oklabM1Inv, Values{lms[0] * lms[0] * lms[0], lms[1] * lms[1] * lms[1], lms[2] * lms[2] * lms[2]})
+}
+
+func (oklabSpace) FromXYZ(x Values) Values {
+	lms := mulVec(oklabM1, x)
+	return mulVec(oklabM2, Values{math.Cbrt(lms[0]), math.Cbrt(lms[1]), math.Cbrt(lms[2])})
+}
+
+func (oklabSpace) Format(v Values) string {
+	return formatCSS("oklab(", v)
+}
+
+// oklchSpace is the cylindrical form of OKLab
+type oklchSpace struct{}
+
+func (oklchSpace) Name() string            { return "OKLCH" }
+func (oklchSpace) ToXYZ(v Values) Values   { return OKLab.ToXYZ(fromPolar(v)) }
+func (oklchSpace) FromXYZ(x Values) Values { return toPolar(OKLab.FromXYZ(x)) }
+
+func (oklchSpace) Format(v Values) string {
+	return fmt.Sprintf("oklch(%s %s %s)", formatNumber(v[0], 4), formatNumber(v[1], 4), formatNumber(v[2], 2))
+}
+
+// toPolar turns L, a, b into L, chroma, hue in degrees
+func toPolar(v Values) Values {
+	c := math.Hypot(v[1], v[2])
+	if c < 1e-4 {
+		return Values{v[0], 0, 0} //achromatic
+	}
+	h := math.Atan2(v[2], v[1]) * 180 / math.Pi
+	if h < 0 {
+		h += 360
+	}
+	return Values{v[0], c, h}
+}
+
+// fromPolar turns L, chroma, hue back into L, a, b
+func fromPolar(v Values) Values {
+	rad := v[2] * math.Pi / 180
+	return Values{v[0], v[1] * math.Cos(rad), v[1] * math.Sin(rad)}
+}
+
+// formatCSS prints space separated channels after the given prefix and closes the function
+func formatCSS(prefix string, v Values) string {
+	return fmt.Sprintf("%s%s %s %s)", prefix, formatChannel(v[0]), formatChannel(v[1]), formatChannel(v[2]))
+}
+
+// formatNumber prints up to the given number of decimals without trailing zeros
+func formatNumber(v float64, decimals int) string {
+	s := fmt.Sprintf("%.*f", decimals, v)
+	for decimals > 0 && s[len(s)-1] == '0' {
+		s = s[:len(s)-1]
+	}
+	if s[len(s)-1] == '.' {
+		s = s[:len(s)-1]
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
@@ -0,0 +1,157 @@
+package color
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const templatesFileName = "templates.json"
+
+// Template is a user-defined copy format such as "brand.color(0x{HEX})".
+//
+// Placeholders:
+//
+//	{r} {g} {b} {a}        channels as 0-255 integers
+//	{rf} {gf} {bf} {af}    channels as 0..1 fractions
+//	{hex} {HEX}            rrggbb in lower or upper case
+//	{h} {s} {l}            HSL hue in degrees, saturation and lightness in percent
+//	{ok_l} {ok_c} {ok_h}   OKLCH lightness, chroma and hue
+//
+// Numeric placeholders take an optional precision, e.g. {ok_c:3}.
+type Template struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_]+)(?::(\d+))?\}`)
+
+// placeholder returns the value of a placeholder and its default precision
+func placeholder(c *Color, name string) (float64, int, bool) {
+	hsl := c.To(HSL)
+	oklch := c.To(OKLCH)
+
+	switch name {
+	case "r":
+		return float64(c.R), 0, true
+	case "g":
+		return float64(c.G), 0, true
+	case "b":
+		return float64(c.B), 0, true
+	case "a":
+		return 255, 0, true
+	case "rf":
+		return float64(c.R) / 255, 3, true
+	case "gf":
+		return float64(c.G) / 255, 3, true
+	case "bf":
+		return float64(c.B) / 255, 3, true
+	case "af":
+		return 1, 3, true
+	case "h":
+		return hsl[0], 0, true
+	case "s":
+		return hsl[1] * 100, 0, true
+	case "l":
+		return hsl[2] * 100, 0, true
+	case "ok_l":
+		return oklch[0], 4, true
+	case "ok_c":
+		return oklch[1], 4, true
+	case "ok_h":
+		return oklch[2], 2, true
+	}
+	return 0, 0, false
+}
+
+// Render fills in the placeholders for the given color. Unknown
+// placeholders are left untouched.
+func (t *Template) Render(c *Color) string {
+	return placeholderPattern.ReplaceAllStringFunc(t.Pattern, func(match string) string {
+		m := placeholderPattern.FindStringSubmatch(match)
+		name, precision := m[1], m[2]
+
+		switch name {
+		case "hex":
+			return strings.TrimPrefix(c.ToHex(), "#")
+		case "HEX":
+			return strings.ToUpper(strings.TrimPrefix(c.ToHex(), "#"))
+		}
+
+		v, digits, ok := placeholder(c, name)
+		if !ok {
+			return match
+		}
+		if precision != "" {
+			digits, _ = strconv.Atoi(precision)
+		}
+		return strconv.FormatFloat(v, 'f', digits, 64)
+	})
+}
+
+// Validate reports an empty name or pattern and unknown placeholders
+func (t *Template) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return fmt.Errorf("template name is empty")
+	}
+	if strings.TrimSpace(t.Pattern) == "" {
+		return fmt.Errorf("template pattern is empty")
+	}
+
+	for _, m := range placeholderPattern.FindAllStringSubmatch(t.Pattern, -1) {
+		if m[1] == "hex" || m[1] == "HEX" {
+			continue
+		}
+		if _, _, ok := placeholder(NewColor(0, 0, 0), m[1]); !ok {
+			return fmt.Errorf("unknown placeholder: %s", m[0])
+		}
+	}
+	return nil
+}
+
+// templatesFile is the layout of templates.json. Older files hold just the
+// template list.
+type templatesFile struct {
+	Active    string      `json:"active,omitempty"` //Used by the copy shortcut
+	Templates []*Template `json:"templates"`
+}
+
+// SaveTemplates writes the user templates and the active template's name to the config directory
+func SaveTemplates(templates []*Template, active string) error {
+	file, err := configFile(templatesFileName)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(templatesFile{Active: active, Templates: templates}, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, data, 0644)
+}
+
+// LoadTemplates reads the user templates and the active template's name from the config directory
+func LoadTemplates() ([]*Template, string, error) {
+	file, err := configFile(templatesFileName)
+	if err != nil {
+		return nil, "", err
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, "", err //File doesn't exist yet
+	}
+
+	var loaded templatesFile
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		//Fall back to the old plain list
+		if err := json.Unmarshal(data, &loaded.Templates); err != nil {
+			return nil, "", err
+		}
+	}
+	return loaded.Templates, loaded.Active, nil
+}
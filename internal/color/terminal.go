@@ -0,0 +1,141 @@
+package color
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+const terminalThemeFileName = "terminal-theme.json"
+
+// ErrTerminalSlot is returned for slot numbers outside 0..TerminalSlots-1
+var ErrTerminalSlot = errors.New("no such terminal theme slot")
+
+// Terminal theme slots after the 16 ANSI colors
+const (
+	SlotBackground = 16 + iota
+	SlotForeground
+	SlotCursor
+
+	TerminalSlots
+)
+
+// TerminalSlotNames labels every terminal theme slot in order
+var TerminalSlotNames = []string{
+	"Black", "Red", "Green", "Yellow", "Blue", "Magenta", "Cyan", "White",
+	"Bright Black", "Bright Red", "Bright Green", "Bright Yellow",
+	"Bright Blue", "Bright Magenta", "Bright Cyan", "Bright White",
+	"Background", "Foreground", "Cursor",
+}
+
+// TerminalTheme is a terminal color scheme: the 16 ANSI colors plus the
+// default background, foreground and cursor colors, stored as hex codes
+type TerminalTheme struct {
+	Name       string     `json:"name"`
+	ANSI       [16]string `json:"ansi"`
+	Background string     `json:"background"`
+	Foreground string     `json:"foreground"`
+	Cursor     string     `json:"cursor"`
+}
+
+// NewTerminalTheme creates a theme with the xterm default colors
+func NewTerminalTheme() *TerminalTheme {
+	t := &TerminalTheme{
+		Name:       "Ladle",
+		Background: "#000000",
+		Foreground: ANSI16Palette[7].ToHex(),
+		Cursor:     ANSI16Palette[7].ToHex(),
+	}
+	for i, c := range ANSI16Palette {
+		t.ANSI[i] = c.ToHex()
+	}
+	return t
+}
+
+// Slot returns the color in a slot, 0..15 being the ANSI colors. Unknown
+// slots read as black.
+func (t *TerminalTheme) Slot(slot int) *Color {
+	hex, err := t.slot(slot)
+	if err != nil {
+		return NewColor(0, 0, 0)
+	}
+	col, err := NewColorHex(*hex)
+	if err != nil {
+		return NewColor(0, 0, 0)
+	}
+	return col
+}
+
+// SetSlot changes the color in a slot
+func (t *TerminalTheme) SetSlot(slot int, hex string) error {
+	target, err := t.slot(slot)
+	if err != nil {
+		return err
+	}
+	if _, err := NewColorHex(hex); err != nil {
+		return err
+	}
+	*target = hex
+	return nil
+}
+
+func (t *TerminalTheme) slot(slot int) (*string, error) {
+	switch slot {
+	case SlotBackground:
+		return &t.Background, nil
+	case SlotForeground:
+		return &t.Foreground, nil
+	case SlotCursor:
+		return &t.Cursor, nil
+	}
+	if slot < 0 || slot >= len(t.ANSI) {
+		return nil, fmt.Errorf("%w: %d", ErrTerminalSlot, slot)
+	}
+	return &t.ANSI[slot], nil
+}
+
+// SaveTerminalTheme writes the terminal theme next to the palette file
+func SaveTerminalTheme(t *TerminalTheme) error {
+	file, err := configFile(terminalThemeFileName)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(t, "", " ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, data, 0644)
+}
+
+// LoadTerminalTheme reads the terminal theme from the config directory.
+// Missing or invalid slots keep their xterm defaults.
+func LoadTerminalTheme() (*TerminalTheme, error) {
+	file, err := configFile(terminalThemeFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err //File doesn't exist yet
+	}
+
+	var loaded TerminalTheme
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return nil, err
+	}
+
+	t := NewTerminalTheme()
+	if loaded.Name != "" {
+		t.Name = loaded.Name
+	}
+	for slot := 0; slot < TerminalSlots; slot++ {
+		if hex, err := loaded.slot(slot); err == nil {
+			t.SetSlot(slot, *hex) //An invalid hex keeps the default
+		}
+	}
+	return t, nil
+}
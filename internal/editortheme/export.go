@@ -0,0 +1,35 @@
+// Package editortheme generates code editor color schemes from an editor
+// theme's role colors
+package editortheme
+
+import (
+	"io"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Exporter writes a theme for one editor
+type Exporter struct {
+	Name      string
+	Extension string //Including the dot
+	Encode    func(w io.Writer, t *color.EditorTheme) error
+
+	// BaseName derives the file name from the theme, defaults to the theme name
+	BaseName func(t *color.EditorTheme) string
+}
+
+// Exporters lists every supported editor in menu order
+var Exporters = []*Exporter{
+	{Name: "VS Code", Extension: ".json", Encode: encodeVSCode},
+	{Name: "Vim / Neovim", Extension: ".vim", Encode: encodeVim, BaseName: func(t *color.EditorTheme) string {
+		return vimName(t.Name) //Vim finds colorschemes by file name
+	}},
+}
+
+// FileName suggests a file name for the exported theme
+func (e *Exporter) FileName(t *color.EditorTheme) string {
+	if e.BaseName != nil {
+		return e.BaseName(t) + e.Extension
+	}
+	return t.Name + e.Extension
+}
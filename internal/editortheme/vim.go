@@ -0,0 +1,96 @@
+package editortheme
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// vimGroup is a highlight group and the roles it is drawn with
+type vimGroup struct {
+	group string
+	fg    string
+	bg    string
+	style string
+}
+
+var vimGroups = []vimGroup{
+	{"Normal", color.RoleForeground, color.RoleBackground, ""},
+	{"Visual", "", color.RoleSelection, ""},
+	{"CursorLine", "", color.RoleLineHighlight, "NONE"},
+	{"CursorLineNr", color.RoleForeground, color.RoleLineHighlight, "NONE"},
+	{"LineNr", color.RoleComment, "", ""},
+	{"Cursor", color.RoleBackground, color.RoleCursor, ""},
+	{"StatusLine", color.RoleForeground, color.RoleSelection, "NONE"},
+	{"Pmenu", color.RoleForeground, color.RoleLineHighlight, ""},
+	{"PmenuSel", color.RoleForeground, color.RoleSelection, ""},
+	{"Comment", color.RoleComment, "", "italic"},
+	{"Statement", color.RoleKeyword, "", "NONE"},
+	{"Keyword", color.RoleKeyword, "", ""},
+	{"String", color.RoleString, "", ""},
+	{"Number", color.RoleNumber, "", ""},
+	{"Float", color.RoleNumber, "", ""},
+	{"Function", color.RoleFunction, "", ""},
+	{"Type", color.RoleType, "", "NONE"},
+	{"Identifier", color.RoleVariable, "", "NONE"},
+	{"Constant", color.RoleConstant, "", ""},
+	{"Operator", color.RoleOperator, "", ""},
+	{"Error", color.RoleError, "", "NONE"},
+	{"ErrorMsg", color.RoleError, "", ""},
+	{"WarningMsg", color.RoleWarning, "", ""},
+	{"DiagnosticError", color.RoleError, "", ""},
+	{"DiagnosticWarn", color.RoleWarning, "", ""},
+}
+
+// encodeVim writes a colorscheme for Vim's or Neovim's colors directory,
+// with xterm-256 fallbacks for terminals without true color
+func encodeVim(w io.Writer, t *color.EditorTheme) error {
+	name := vimName(t.Name)
+	background := "light"
+	if t.Dark() {
+		background = "dark"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "\" %s\n", t.Name)
+	fmt.Fprintf(bw, "set background=%s\n", background)
+	fmt.Fprint(bw, "hi clear\nif exists(\"syntax_on\")\n  syntax reset\nendif\n")
+	fmt.Fprintf(bw, "let g:colors_name = \"%s\"\n\n", name)
+
+	for _, g := range vimGroups {
+		var attrs []string
+		if g.fg != "" {
+			c := t.Role(g.fg)
+			attrs = append(attrs, "guifg="+c.ToHex(), fmt.Sprintf("ctermfg=%d", c.NearestXterm256()))
+		}
+		if g.bg != "" {
+			c := t.Role(g.bg)
+			attrs = append(attrs, "guibg="+c.ToHex(), fmt.Sprintf("ctermbg=%d", c.NearestXterm256()))
+		}
+		if g.style != "" {
+			attrs = append(attrs, "gui="+g.style, "cterm="+g.style)
+		}
+		fmt.Fprintf(bw, "hi %s %s\n", g.group, strings.Join(attrs, " "))
+	}
+	return bw.Flush()
+}
+
+// vimName turns a theme name into a colorscheme name, which is also its file name
+func vimName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "ladle"
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package editortheme
+
+import (
+	"encoding/json"
+	"io"
+
+	"ladle-color-picker/internal/color"
+)
+
+type vscodeTheme struct {
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Colors      map[string]string `json:"colors"`
+	TokenColors []vscodeToken     `json:"tokenColors"`
+}
+
+type vscodeToken struct {
+	Name     string        `json:"name"`
+	Scope    []string      `json:"scope"`
+	Settings vscodeSetting `json:"settings"`
+}
+
+type vscodeSetting struct {
+	Foreground string `json:"foreground"`
+	FontStyle  string `json:"fontStyle,omitempty"`
+}
+
+// vscodeScopes maps syntax roles to TextMate scopes
+var vscodeScopes = []struct {
+	role      string
+	name      string
+	fontStyle string
+	scopes    []string
+}{
+	{color.RoleComment, "Comment", "italic", []string{"comment", "punctuation.definition.comment"}},
+	{color.RoleKeyword, "Keyword", "", []string{"keyword", "storage.type", "storage.modifier"}},
+	{color.RoleString, "String", "", []string{"string", "punctuation.definition.string"}},
+	{color.RoleNumber, "Number", "", []string{"constant.numeric"}},
+	{color.RoleFunction, "Function", "", []string{"entity.name.function", "support.function", "meta.function-call"}},
+	{color.RoleType, "Type", "", []string{"entity.name.type", "entity.name.class", "support.type", "support.class"}},
+	{color.RoleVariable, "Variable", "", []string{"variable", "meta.definition.variable.name", "support.variable"}},
+	{color.RoleConstant, "Constant", "", []string{"constant", "variable.other.constant", "support.constant"}},
+	{color.RoleOperator, "Operator", "", []string{"keyword.operator", "punctuation"}},
+	{color.RoleError, "Invalid", "", []string{"invalid", "invalid.illegal"}},
+}
+
+// encodeVSCode writes a color theme file for a VS Code extension's themes folder
+func encodeVSCode(w io.Writer, t *color.EditorTheme) error {
+	hex := func(role string) string { return t.Role(role).ToHex() }
+
+	theme := vscodeTheme{
+		Name: t.Name,
+		Type: "light",
+		Colors: map[string]string{
+			"editor.background":                hex(color.RoleBackground),
+			"editor.foreground":                hex(color.RoleForeground),
+			"editor.selectionBackground":       hex(color.RoleSelection),
+			"editor.lineHighlightBackground":   hex(color.RoleLineHighlight),
+			"editorCursor.foreground":          hex(color.RoleCursor),
+			"editorLineNumber.foreground":      hex(color.RoleComment),
+			"editorError.foreground":           hex(color.RoleError),
+			"editorWarning.foreground":         hex(color.RoleWarning),
+			"editorGutter.background":          hex(color.RoleBackground),
+			"sideBar.background":               hex(color.RoleLineHighlight),
+			"sideBar.foreground":               hex(color.RoleForeground),
+			"activityBar.background":           hex(color.RoleLineHighlight),
+			"statusBar.background":             hex(color.RoleSelection),
+			"statusBar.foreground":             hex(color.RoleForeground),
+			"titleBar.activeBackground":        hex(color.RoleLineHighlight),
+			"editorGroupHeader.tabsBackground": hex(color.RoleLineHighlight),
+			"tab.activeBackground":             hex(color.RoleBackground),
+			"tab.inactiveBackground":           hex(color.RoleLineHighlight),
+			"terminal.background":              hex(color.RoleBackground),
+			"terminal.foreground":              hex(color.RoleForeground),
+		},
+	}
+	if t.Dark() {
+		theme.Type = "dark"
+	}
+
+	for _, s := range vscodeScopes {
+		theme.TokenColors = append(theme.TokenColors, vscodeToken{
+			Name:     s.name,
+			Scope:    s.scopes,
+			Settings: vscodeSetting{Foreground: hex(s.role), FontStyle: s.fontStyle},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(theme)
+}
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DebouncedWriter batches frequent writes: only the latest data is written,
+// once no new data has arrived for the configured delay.
+type DebouncedWriter struct {
+	mu      sync.Mutex
+	delay   time.Duration
+	timer   *time.Timer
+	pending []byte
+	write   func([]byte) error
+}
+
+// NewDebouncedWriter creates a writer that calls write after delay of quiet
+func NewDebouncedWriter(delay time.Duration, write func([]byte) error) *DebouncedWriter {
+	return &DebouncedWriter{delay: delay, write: write}
+}
+
+// Schedule queues data to be written, replacing anything still pending.
+// The data must not be modified afterwards.
+func (w *DebouncedWriter) Schedule(data []byte) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.pending = data
+	if w.timer != nil {
+		w.timer.Stop()
+	}
+	w.timer = time.AfterFunc(w.delay, w.Flush)
+}
+
+// Flush writes pending data immediately
+func (w *DebouncedWriter) Flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.timer != nil {
+		w.timer.Stop()
+		w.timer = nil
+	}
+	if w.pending == nil {
+		return
+	}
+
+	if err := w.write(w.pending); err != nil {
+		fmt.Printf("could not write file: %v\n", err)
+	}
+	w.pending = nil
+}
@@ -0,0 +1,155 @@
+package swatches
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"unicode/utf16"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Photoshop color swatches (.aco). A file holds a version 1 section without
+// names, usually followed by a version 2 section repeating the colors with names.
+// All numbers are big-endian 16-bit.
+
+const (
+	acoRGB       = 0
+	acoHSB       = 1
+	acoCMYK      = 2
+	acoLab       = 7
+	acoGrayscale = 8
+)
+
+func decodeACO(r io.Reader) (*Palette, error) {
+	//Reading it all up front lets every length field be checked against what is left
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	br := bytes.NewReader(data)
+
+	v1, err := readACOSection(br, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	//The named section is optional; fall back to the plain one when it is missing or damaged
+	v2, err := readACOSection(br, 2)
+	if err != nil || len(v2) == 0 {
+		return &Palette{Swatches: v1}, nil
+	}
+	return &Palette{Swatches: v2}, nil
+}
+
+func readACOSection(r *bytes.Reader, version uint16) ([]Swatch, error) {
+	var header [2]uint16
+	if err := binary.Read(r, binary.BigEndian, &header); err != nil {
+		if version == 2 && errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if header[0] != version {
+		return nil, ErrInvalidFile
+	}
+
+	//Each entry takes at least 10 bytes
+	if int(header[1])*10 > r.Len() {
+		return nil, fmt.Errorf("%w: more colors than the file holds", ErrInvalidFile)
+	}
+	swatches := make([]Swatch, 0, header[1])
+	for i := 0; i < int(header[1]); i++ {
+		var entry struct {
+			Space  uint16
+			Values [4]uint16
+		}
+		if err := binary.Read(r, binary.BigEndian, &entry); err != nil {
+			return nil, err
+		}
+
+		s, err := acoSwatch(entry.Space, entry.Values)
+		if err != nil {
+			return nil, err
+		}
+
+		if version == 2 {
+			var length uint32
+			if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+				return nil, err
+			}
+			if s.Name, err = readUTF16Units(r, int64(length)); err != nil {
+				return nil, err
+			}
+		}
+		swatches = append(swatches, s)
+	}
+	return swatches, nil
+}
+
+func acoSwatch(space uint16, v [4]uint16) (Swatch, error) {
+	unit := func(i int) float64 { return float64(v[i]) / 65535 }
+
+	var s Swatch
+	switch space {
+	case acoRGB:
+		s.Color = color.NewColor(uint8(v[0]>>8), uint8(v[1]>>8), uint8(v[2]>>8))
+	case acoHSB:
+		s.Color = color.FromSpace(color.Values{unit(0) * 360, unit(1), unit(2)}, color.HSV)
+	case acoCMYK:
+		//Stored inverted: 0 is full ink
+		s.CMYK = []float64{1 - unit(0), 1 - unit(1), 1 - unit(2), 1 - unit(3)}
+		s.Color = color.FromCMYK(s.CMYK[0], s.CMYK[1], s.CMYK[2], s.CMYK[3])
+	case acoLab:
+		//Lightness is 0..10000, a and b are signed hundredths
+		s.Color = color.FromSpace(color.Values{float64(v[0]) / 100, float64(int16(v[1])) / 100, float64(int16(v[2])) / 100}, color.Lab)
+	case acoGrayscale:
+		//0..10000 of black ink
+		g := 1 - float64(v[0])/10000
+		s.Color = color.FromSpace(color.Values{g, g, g}, color.SRGB)
+	default:
+		return Swatch{}, ErrInvalidFile
+	}
+	return s, nil
+}
+
+func encodeACO(w io.Writer, p *Palette) error {
+	//The color count is a 16-bit field
+	if len(p.Swatches) > math.MaxUint16 {
+		return fmt.Errorf("%w: Photoshop swatches hold at most %d", ErrTooManyColors, math.MaxUint16)
+	}
+
+	var buf bytes.Buffer
+	for _, version := range []uint16{1, 2} {
+		binary.Write(&buf, binary.BigEndian, []uint16{version, uint16(len(p.Swatches))})
+		for _, s := range p.Swatches {
+			if len(s.CMYK) == 4 {
+				binary.Write(&buf, binary.BigEndian, []uint16{
+					acoCMYK, unit16(1 - s.CMYK[0]), unit16(1 - s.CMYK[1]), unit16(1 - s.CMYK[2]), unit16(1 - s.CMYK[3]),
+				})
+			} else {
+				//Repeating the byte fills the 16-bit range exactly (0xff -> 0xffff)
+				binary.Write(&buf, binary.BigEndian, []uint16{
+					acoRGB, uint16(s.Color.R) * 257, uint16(s.Color.G) * 257, uint16(s.Color.B) * 257, 0,
+				})
+			}
+
+			if version == 2 {
+				units := append(utf16.Encode([]rune(s.label())), 0)
+				binary.Write(&buf, binary.BigEndian, uint32(len(units)))
+				binary.Write(&buf, binary.BigEndian, units)
+			}
+		}
+	}
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// unit16 scales a 0..1 value to the 0..65535 range
+func unit16(v float64) uint16 {
+	return uint16(math.Round(math.Max(0, math.Min(1, v)) * 65535))
+}
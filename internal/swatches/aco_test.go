@@ -0,0 +1,105 @@
+package swatches
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"ladle-color-picker/internal/color"
+)
+
+func TestACORoundTripNamesAndCMYK(t *testing.T) {
+	want := &Palette{Swatches: []Swatch{
+		{Name: "Brand Red", Color: color.NewColor(0xe6, 0x39, 0x46)},
+		{Name: "Print", Color: color.FromCMYK(1, 0, 0, 0), CMYK: []float64{1, 0, 0, 0}},
+	}}
+
+	var buf bytes.Buffer
+	if err := encodeACO(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeACO(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Swatches) != len(want.Swatches) {
+		t.Fatalf("got %d swatches, want %d", len(got.Swatches), len(want.Swatches))
+	}
+	for i, s := range got.Swatches {
+		w := want.Swatches[i]
+		if s.Name != w.Name || s.Color.ToHex() != w.Color.ToHex() {
+			t.Errorf("swatch %d: got %q %s, want %q %s", i, s.Name, s.Color.ToHex(), w.Name, w.Color.ToHex())
+		}
+	}
+}
+
+// acoFile builds a file from 16-bit words followed by raw bytes
+func acoFile(words []uint16, tail ...byte) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, words)
+	buf.Write(tail)
+	return buf.Bytes()
+}
+
+func TestDecodeACOVersion1Only(t *testing.T) {
+	got, err := decodeACO(bytes.NewReader(acoFile([]uint16{1, 1, acoRGB, 0xffff, 0, 0, 0})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Swatches) != 1 || got.Swatches[0].Color.ToHex() != "#ff0000" {
+		t.Errorf("got %+v", got.Swatches)
+	}
+}
+
+func TestDecodeACOCorrupt(t *testing.T) {
+	v1 := []uint16{1, 1, acoRGB, 0xffff, 0, 0, 0}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		invalid bool //Must be reported as ErrInvalidFile rather than a read error
+	}{
+		{"empty", nil, false},
+		{"wrong version", acoFile([]uint16{3, 0}), true},
+		{"more colors than the file holds", acoFile([]uint16{1, 0xffff, acoRGB, 0, 0, 0, 0}), true},
+		{"unknown color space", acoFile([]uint16{1, 1, 99, 0, 0, 0, 0}), true},
+		{"truncated entry", acoFile([]uint16{1, 1, acoRGB, 0}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeACO(bytes.NewReader(tt.data))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.invalid && !errors.Is(err, ErrInvalidFile) {
+				t.Errorf("got %v, want ErrInvalidFile", err)
+			}
+		})
+	}
+
+	//A damaged named section falls back to the plain colors instead of failing
+	huge := append(append([]uint16{}, v1...), 2, 1, acoRGB, 0, 0xffff, 0, 0, 0xffff, 0xffff)
+	got, err := decodeACO(bytes.NewReader(acoFile(huge)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Swatches) != 1 || got.Swatches[0].Color.ToHex() != "#ff0000" {
+		t.Errorf("huge name length: got %+v", got.Swatches)
+	}
+	if _, err := readACOSection(bytes.NewReader(acoFile(huge[len(v1):])), 2); !errors.Is(err, ErrInvalidFile) {
+		t.Errorf("huge name length: got %v, want ErrInvalidFile", err)
+	}
+}
+
+func TestEncodeACOTooManyColors(t *testing.T) {
+	p := &Palette{Swatches: make([]Swatch, 1<<16)}
+	for i := range p.Swatches {
+		p.Swatches[i].Color = color.NewColor(uint8(i), uint8(i>>8), 0)
+	}
+	if err := encodeACO(&bytes.Buffer{}, p); !errors.Is(err, ErrTooManyColors) {
+		t.Errorf("got %v, want ErrTooManyColors", err)
+	}
+}
@@ -0,0 +1,212 @@
+package swatches
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"unicode/utf16"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Adobe Swatch Exchange (.ase), used by Photoshop, Illustrator and InDesign.
+// All numbers are big-endian; strings are length-prefixed, null-terminated UTF-16.
+
+const (
+	aseGroupStart = 0xC001
+	aseGroupEnd   = 0xC002
+	aseColorEntry = 0x0001
+
+	aseTypeGlobal = 0
+	aseTypeSpot   = 1
+	aseTypeNormal = 2
+)
+
+func decodeASE(r io.Reader) (*Palette, error) {
+	//Reading it all up front lets every length field be checked against what is left
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	br := bytes.NewReader(data)
+
+	var header struct {
+		Signature    [4]byte
+		Major, Minor uint16
+		Blocks       uint32
+	}
+	if err := binary.Read(br, binary.BigEndian, &header); err != nil {
+		return nil, err
+	}
+	if string(header.Signature[:]) != "ASEF" {
+		return nil, ErrInvalidFile
+	}
+
+	p := &Palette{}
+	group := ""
+	for i := uint32(0); i < header.Blocks; i++ {
+		var blockType uint16
+		var length uint32
+		if err := binary.Read(br, binary.BigEndian, &blockType); err != nil {
+			return nil, err
+		}
+		if err := binary.Read(br, binary.BigEndian, &length); err != nil {
+			return nil, err
+		}
+
+		if int64(length) > int64(br.Len()) {
+			return nil, fmt.Errorf("%w: block longer than the file", ErrInvalidFile)
+		}
+		block := make([]byte, length)
+		if _, err := io.ReadFull(br, block); err != nil {
+			return nil, err
+		}
+		body := bytes.NewReader(block)
+
+		switch blockType {
+		case aseGroupStart:
+			name, err := readUTF16(body)
+			if err != nil {
+				return nil, err
+			}
+			group = name
+		case aseGroupEnd:
+			group = ""
+		case aseColorEntry:
+			s, err := readASEColor(body)
+			if err != nil {
+				return nil, err
+			}
+			s.Group = group
+			p.Swatches = append(p.Swatches, s)
+		}
+	}
+
+	return p, nil
+}
+
+func readASEColor(r *bytes.Reader) (Swatch, error) {
+	name, err := readUTF16(r)
+	if err != nil {
+		return Swatch{}, err
+	}
+
+	var model [4]byte
+	if err := binary.Read(r, binary.BigEndian, &model); err != nil {
+		return Swatch{}, err
+	}
+
+	count := map[string]int{"RGB ": 3, "CMYK": 4, "LAB ": 3, "Gray": 1}[string(model[:])]
+	if count == 0 {
+		return Swatch{}, fmt.Errorf("%w: unsupported color model %q", ErrInvalidFile, model[:])
+	}
+	values := make([]float32, count)
+	if err := binary.Read(r, binary.BigEndian, values); err != nil {
+		return Swatch{}, err
+	}
+
+	s := Swatch{Name: name}
+	switch string(model[:]) {
+	case "RGB ":
+		s.Color = color.FromSpace(color.Values{float64(values[0]), float64(values[1]), float64(values[2])}, color.SRGB)
+	case "CMYK":
+		s.CMYK = []float64{float64(values[0]), float64(values[1]), float64(values[2]), float64(values[3])}
+		s.Color = color.FromCMYK(s.CMYK[0], s.CMYK[1], s.CMYK[2], s.CMYK[3])
+	case "LAB ":
+		//Lightness is stored as 0..1, a and b as-is
+		s.Color = color.FromSpace(color.Values{float64(values[0]) * 100, float64(values[1]), float64(values[2])}, color.Lab)
+	case "Gray":
+		v := float64(values[0])
+		s.Color = color.FromSpace(color.Values{v, v, v}, color.SRGB)
+	}
+	return s, nil
+}
+
+func encodeASE(w io.Writer, p *Palette) error {
+	var blocks bytes.Buffer
+	count := uint32(0)
+
+	group := ""
+	for _, s := range p.Swatches {
+		if s.Group != group {
+			if group != "" {
+				writeASEBlock(&blocks, aseGroupEnd, nil)
+				count++
+			}
+			if s.Group != "" {
+				var body bytes.Buffer
+				writeUTF16(&body, s.Group)
+				writeASEBlock(&blocks, aseGroupStart, body.Bytes())
+				count++
+			}
+			group = s.Group
+		}
+
+		var body bytes.Buffer
+		writeUTF16(&body, s.label())
+		if len(s.CMYK) == 4 {
+			body.WriteString("CMYK")
+			binary.Write(&body, binary.BigEndian, []float32{float32(s.CMYK[0]), float32(s.CMYK[1]), float32(s.CMYK[2]), float32(s.CMYK[3])})
+		} else {
+			body.WriteString("RGB ")
+			binary.Write(&body, binary.BigEndian, []float32{float32(s.Color.R) / 255, float32(s.Color.G) / 255, float32(s.Color.B) / 255})
+		}
+		binary.Write(&body, binary.BigEndian, uint16(aseTypeGlobal))
+		writeASEBlock(&blocks, aseColorEntry, body.Bytes())
+		count++
+	}
+	if group != "" {
+		writeASEBlock(&blocks, aseGroupEnd, nil)
+		count++
+	}
+
+	var header bytes.Buffer
+	header.WriteString("ASEF")
+	binary.Write(&header, binary.BigEndian, []uint16{1, 0})
+	binary.Write(&header, binary.BigEndian, count)
+
+	if _, err := w.Write(header.Bytes()); err != nil {
+		return err
+	}
+	_, err := w.Write(blocks.Bytes())
+	return err
+}
+
+func writeASEBlock(buf *bytes.Buffer, blockType uint16, body []byte) {
+	binary.Write(buf, binary.BigEndian, blockType)
+	binary.Write(buf, binary.BigEndian, uint32(len(body)))
+	buf.Write(body)
+}
+
+// readUTF16 reads a uint16 length (in code units, including the terminator) followed by UTF-16BE text
+func readUTF16(r *bytes.Reader) (string, error) {
+	var length uint16
+	if err := binary.Read(r, binary.BigEndian, &length); err != nil {
+		return "", err
+	}
+	return readUTF16Units(r, int64(length))
+}
+
+// readUTF16Units reads n UTF-16BE code units, dropping the null terminator.
+// Lengths come from the file, so they are checked against the remaining input.
+func readUTF16Units(r *bytes.Reader, n int64) (string, error) {
+	if n < 0 || n*2 > int64(r.Len()) {
+		return "", fmt.Errorf("%w: text longer than the file", ErrInvalidFile)
+	}
+	units := make([]uint16, n)
+	if err := binary.Read(r, binary.BigEndian, units); err != nil {
+		return "", err
+	}
+	for len(units) > 0 && units[len(units)-1] == 0 {
+		units = units[:len(units)-1]
+	}
+	return string(utf16.Decode(units)), nil
+}
+
+// writeUTF16 writes the counterpart of readUTF16
+func writeUTF16(buf *bytes.Buffer, s string) {
+	units := append(utf16.Encode([]rune(s)), 0)
+	binary.Write(buf, binary.BigEndian, uint16(len(units)))
+	binary.Write(buf, binary.BigEndian, units)
+}
@@ -0,0 +1,112 @@
+package swatches
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"ladle-color-picker/internal/color"
+)
+
+func TestASERoundTripGroupsAndCMYK(t *testing.T) {
+	want := &Palette{Swatches: []Swatch{
+		{Name: "Loose", Color: color.NewColor(0x11, 0x22, 0x33)},
+		{Name: "Grouped", Color: color.NewColor(0xaa, 0xbb, 0xcc), Group: "Brand"},
+		{Name: "Print", Color: color.FromCMYK(0, 1, 1, 0), Group: "Brand", CMYK: []float64{0, 1, 1, 0}},
+	}}
+
+	var buf bytes.Buffer
+	if err := encodeASE(&buf, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeASE(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got.Swatches) != len(want.Swatches) {
+		t.Fatalf("got %d swatches, want %d", len(got.Swatches), len(want.Swatches))
+	}
+	for i, s := range got.Swatches {
+		w := want.Swatches[i]
+		if s.Name != w.Name || s.Group != w.Group || s.Color.ToHex() != w.Color.ToHex() {
+			t.Errorf("swatch %d: got %q/%q %s, want %q/%q %s", i, s.Group, s.Name, s.Color.ToHex(), w.Group, w.Name, w.Color.ToHex())
+		}
+	}
+	if cmyk := got.Swatches[2].CMYK; len(cmyk) != 4 || cmyk[1] != 1 {
+		t.Errorf("CMYK values lost: %v", cmyk)
+	}
+}
+
+// aseFile builds a file from raw blocks, claiming the given block count
+func aseFile(count uint32, blocks ...[]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("ASEF")
+	binary.Write(&buf, binary.BigEndian, []uint16{1, 0})
+	binary.Write(&buf, binary.BigEndian, count)
+	for _, b := range blocks {
+		buf.Write(b)
+	}
+	return buf.Bytes()
+}
+
+func aseBlock(blockType uint16, length uint32, body []byte) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, blockType)
+	binary.Write(&buf, binary.BigEndian, length)
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+func TestDecodeASECorrupt(t *testing.T) {
+	hugeName := []byte{0xff, 0xff, 0, 'A'}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		invalid bool //Must be reported as ErrInvalidFile rather than a read error
+	}{
+		{"empty", nil, false},
+		{"bad signature", append([]byte("ASEX"), make([]byte, 8)...), true},
+		{"truncated header", []byte("ASEF\x00\x01"), false},
+		{"missing blocks", aseFile(3), false},
+		{"huge block length", aseFile(1, aseBlock(aseColorEntry, 0xffffffff, nil)), true},
+		{"block past the end", aseFile(1, aseBlock(aseColorEntry, 64, []byte{0, 1, 0})), true},
+		{"huge color name", aseFile(1, aseBlock(aseColorEntry, uint32(len(hugeName)), hugeName)), true},
+		{"huge group name", aseFile(1, aseBlock(aseGroupStart, uint32(len(hugeName)), hugeName)), true},
+		{"unknown color model", aseFile(1, aseBlock(aseColorEntry, 10, []byte{0, 1, 0, 0, 'X', 'Y', 'Z', ' ', 0, 0})), true},
+		{"truncated values", aseFile(1, aseBlock(aseColorEntry, 10, []byte{0, 1, 0, 0, 'R', 'G', 'B', ' ', 0, 0})), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeASE(bytes.NewReader(tt.data))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if tt.invalid && !errors.Is(err, ErrInvalidFile) {
+				t.Errorf("got %v, want ErrInvalidFile", err)
+			}
+		})
+	}
+}
+
+func TestASEGroupsSurviveSavedPalette(t *testing.T) {
+	imported := &Palette{Name: "Brand", Swatches: []Swatch{
+		{Name: "Red", Color: color.NewColor(0xff, 0, 0), Group: "Primary"},
+		{Name: "Gray", Color: color.NewColor(0x80, 0x80, 0x80)},
+	}}
+
+	var buf bytes.Buffer
+	if err := encodeASE(&buf, FromNamed(imported.ToNamed())); err != nil {
+		t.Fatal(err)
+	}
+	got, err := decodeASE(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Swatches) != 2 || got.Swatches[0].Group != "Primary" || got.Swatches[1].Group != "" {
+		t.Errorf("groups lost: %+v", got.Swatches)
+	}
+}
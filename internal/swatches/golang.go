@@ -0,0 +1,114 @@
+package swatches
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Go source exporter: a package with an image/color.RGBA variable per color
+// and a lookup map by name, as written by cmd/palettegen.
+
+// goLookupName is the generated map; colors may not take its name
+const goLookupName = "ByName"
+
+// EncodeGo writes p as Go source in package pkg
+func EncodeGo(w io.Writer, p *Palette, pkg string) error {
+	if !token.IsIdentifier(pkg) {
+		return fmt.Errorf("invalid package name %q", pkg)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by Ladle from the %q palette. DO NOT EDIT.\n\n", p.Name)
+	fmt.Fprintf(&buf, "// Package %s holds the colors of the %q palette.\n", pkg, p.Name)
+	fmt.Fprintf(&buf, "package %s\n\nimport \"image/color\"\n\n", pkg)
+
+	idents := goIdentifiers(p)
+	for i, s := range p.Swatches {
+		hex := s.Color.ToHex()
+		if name := strings.Join(strings.Fields(s.Name), " "); name != "" && name != idents[i] {
+			fmt.Fprintf(&buf, "// %s is %s (%s).\n", idents[i], hex, name)
+		} else {
+			fmt.Fprintf(&buf, "// %s is %s.\n", idents[i], hex)
+		}
+		if s.Description != "" {
+			fmt.Fprint(&buf, "//\n")
+			for _, line := range strings.Split(strings.TrimSpace(s.Description), "\n") {
+				fmt.Fprintf(&buf, "// %s\n", strings.TrimRight(line, "\r"))
+			}
+		}
+		fmt.Fprintf(&buf, "var %s = color.RGBA{R: 0x%02x, G: 0x%02x, B: 0x%02x, A: 0xff}\n\n", idents[i], s.Color.R, s.Color.G, s.Color.B)
+	}
+
+	fmt.Fprintf(&buf, "// %s looks up the colors by their palette names.\n", goLookupName)
+	fmt.Fprintf(&buf, "var %s = map[string]color.RGBA{\n", goLookupName)
+	seen := make(map[string]bool)
+	for i, s := range p.Swatches {
+		if name := s.label(); !seen[name] {
+			seen[name] = true
+			fmt.Fprintf(&buf, "%q: %s,\n", name, idents[i])
+		}
+	}
+	fmt.Fprint(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// GoPackageName derives a package name from a palette name, like "brandcolors"
+// for "Brand Colors"
+func GoPackageName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || (unicode.IsDigit(r) && b.Len() > 0)) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 || token.IsKeyword(b.String()) {
+		return "palette"
+	}
+	return b.String()
+}
+
+// goIdentifiers returns a unique exported identifier per swatch: the slug in
+// CamelCase, so "Primary/Button" becomes PrimaryButton
+func goIdentifiers(p *Palette) []string {
+	idents := make([]string, len(p.Swatches))
+	taken := map[string]bool{goLookupName: true}
+	for i, slug := range Slugs(p) {
+		var b strings.Builder
+		for _, part := range strings.Split(slug, "-") {
+			for j, r := range part {
+				if j == 0 {
+					r = unicode.ToUpper(r)
+				}
+				b.WriteRune(r)
+			}
+		}
+
+		base := b.String()
+		if !token.IsExported(base) {
+			base = "Color" + base
+		}
+		ident := base
+		for n := 2; taken[ident]; n++ {
+			ident = fmt.Sprintf("%s%d", base, n)
+		}
+		taken[ident] = true
+		idents[i] = ident
+	}
+	return idents
+}
+
+// encodeGoFormat writes the Go package with a name derived from the palette's
+func encodeGoFormat(w io.Writer, p *Palette) error {
+	return EncodeGo(w, p, GoPackageName(p.Name))
+}
@@ -0,0 +1,92 @@
+package swatches
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// GIMP palettes (.gpl), also read and written by Inkscape. A plain text
+// header followed by one "R G B<tab>Name" line per color.
+
+// gplUntitled is what GIMP writes for colors without a name
+const gplUntitled = "Untitled"
+
+var gplColorLine = regexp.MustCompile(`^(\d+)\s+(\d+)\s+(\d+)\s*(.*)$`)
+
+func decodeGPL(r io.Reader) (*Palette, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() || strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff")) != "GIMP Palette" {
+		return nil, ErrInvalidFile
+	}
+
+	p := &Palette{}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if value, ok := cutPrefix(line, "Name:"); ok {
+			p.Name = value
+			continue
+		}
+		if value, ok := cutPrefix(line, "Columns:"); ok {
+			p.Columns, _ = strconv.Atoi(value)
+			continue
+		}
+
+		m := gplColorLine.FindStringSubmatch(line)
+		if m == nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFile, line)
+		}
+		var rgb [3]uint8
+		for i := range rgb {
+			v, err := strconv.ParseUint(m[i+1], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidFile, line)
+			}
+			rgb[i] = uint8(v)
+		}
+
+		s := Swatch{Color: color.NewColor(rgb[0], rgb[1], rgb[2])}
+		if m[4] != gplUntitled {
+			s.Name = m[4]
+		}
+		p.Swatches = append(p.Swatches, s)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func encodeGPL(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "GIMP Palette")
+	fmt.Fprintf(bw, "Name: %s\n", p.Name)
+	if p.Columns > 0 {
+		fmt.Fprintf(bw, "Columns: %d\n", p.Columns)
+	}
+	fmt.Fprintln(bw, "#")
+	for _, s := range p.Swatches {
+		name := s.Name
+		if name == "" {
+			name = gplUntitled
+		}
+		fmt.Fprintf(bw, "%3d %3d %3d\t%s\n", s.Color.R, s.Color.G, s.Color.B, name)
+	}
+	return bw.Flush()
+}
+
+// cutPrefix reports whether line starts with prefix and returns the trimmed rest
+func cutPrefix(line, prefix string) (string, bool) {
+	if !strings.HasPrefix(line, prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(line[len(prefix):]), true
+}
@@ -0,0 +1,63 @@
+package swatches
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// JASC palettes (.pal) from Paint Shop Pro, common in pixel art and retro
+// tooling: a "JASC-PAL" header, version "0100", the color count and one
+// "R G B" line per color. Indexed images cap palettes at 256 entries, and
+// files are often padded to that size with black.
+
+const jascMaxColors = 256
+
+func decodeJASC(r io.Reader) (*Palette, error) {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(lines) < 3 || lines[0] != "JASC-PAL" {
+		return nil, ErrInvalidFile
+	}
+	count, err := strconv.Atoi(lines[2])
+	if err != nil || count < 0 || count > jascMaxColors || len(lines)-3 < count {
+		return nil, fmt.Errorf("%w: bad color count %q", ErrInvalidFile, lines[2])
+	}
+
+	p := &Palette{}
+	for _, line := range lines[3 : 3+count] {
+		var r, g, b uint8
+		if _, err := fmt.Sscanf(line, "%d %d %d", &r, &g, &b); err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFile, line)
+		}
+		p.Swatches = append(p.Swatches, Swatch{Color: color.NewColor(r, g, b)})
+	}
+	return p, nil
+}
+
+func encodeJASC(w io.Writer, p *Palette) error {
+	if len(p.Swatches) > jascMaxColors {
+		return fmt.Errorf("%w: JASC palettes hold at most %d", ErrTooManyColors, jascMaxColors)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "JASC-PAL\r\n0100\r\n")
+	fmt.Fprintf(bw, "%d\r\n", len(p.Swatches))
+	for _, s := range p.Swatches {
+		fmt.Fprintf(bw, "%d %d %d\r\n", s.Color.R, s.Color.G, s.Color.B)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,238 @@
+package swatches
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Krita palettes (.kpl): a zip holding a mimetype marker and colorset.xml.
+// Entries sit at grid positions, either at the top level or in named groups.
+
+const (
+	kplMimeType    = "krita/x-colorset"
+	kplRGBProfile  = "sRGB-elle-V2-srgbtrc.icc"
+	defaultColumns = 16
+)
+
+type kplColorset struct {
+	XMLName  xml.Name   `xml:"Colorset"`
+	Version  string     `xml:"version,attr"`
+	Name     string     `xml:"name,attr"`
+	Columns  int        `xml:"columns,attr"`
+	Rows     int        `xml:"rows,attr"`
+	ReadOnly bool       `xml:"readonly,attr"`
+	Comment  string     `xml:"comment,attr"`
+	Entries  []kplEntry `xml:"ColorSetEntry"`
+	Groups   []kplGroup `xml:"Group"`
+}
+
+type kplGroup struct {
+	Name    string     `xml:"name,attr"`
+	Rows    int        `xml:"rows,attr"`
+	Entries []kplEntry `xml:"ColorSetEntry"`
+}
+
+type kplEntry struct {
+	Name     string       `xml:"name,attr"`
+	ID       string       `xml:"id,attr"`
+	Spot     bool         `xml:"spot,attr"`
+	BitDepth string       `xml:"bitdepth,attr"`
+	RGB      *kplRGB      `xml:"RGB"`
+	CMYK     *kplCMYK     `xml:"CMYK"`
+	Gray     *kplGray     `xml:"Gray"`
+	Lab      *kplLab      `xml:"Lab"`
+	Position *kplPosition `xml:"Position"`
+}
+
+type kplRGB struct {
+	Space string  `xml:"space,attr"`
+	R     float64 `xml:"r,attr"`
+	G     float64 `xml:"g,attr"`
+	B     float64 `xml:"b,attr"`
+}
+
+type kplCMYK struct {
+	Space string  `xml:"space,attr"`
+	C     float64 `xml:"c,attr"`
+	M     float64 `xml:"m,attr"`
+	Y     float64 `xml:"y,attr"`
+	K     float64 `xml:"k,attr"`
+}
+
+type kplGray struct {
+	Space string  `xml:"space,attr"`
+	G     float64 `xml:"g,attr"`
+}
+
+type kplLab struct {
+	Space string  `xml:"space,attr"`
+	L     float64 `xml:"L,attr"`
+	A     float64 `xml:"a,attr"`
+	B     float64 `xml:"b,attr"`
+}
+
+type kplPosition struct {
+	Row    int `xml:"row,attr"`
+	Column int `xml:"column,attr"`
+}
+
+func decodeKPL(r io.Reader) (*Palette, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	var set kplColorset
+	found := false
+	for _, f := range archive.File {
+		if f.Name != "colorset.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = xml.NewDecoder(rc).Decode(&set)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+		}
+		found = true
+	}
+	if !found {
+		return nil, ErrInvalidFile
+	}
+
+	p := &Palette{Name: set.Name, Columns: set.Columns}
+	add := func(entries []kplEntry, group string) {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].before(entries[j]) })
+		for _, e := range entries {
+			if s, ok := e.swatch(); ok {
+				s.Group = group
+				p.Swatches = append(p.Swatches, s)
+			}
+		}
+	}
+	add(set.Entries, "")
+	for _, g := range set.Groups {
+		add(g.Entries, g.Name)
+	}
+	return p, nil
+}
+
+// before orders entries by grid position. Entries without one come after all
+// positioned entries and, with a stable sort, keep their file order.
+func (e kplEntry) before(other kplEntry) bool {
+	if e.Position == nil || other.Position == nil {
+		return e.Position != nil && other.Position == nil
+	}
+	if e.Position.Row != other.Position.Row {
+		return e.Position.Row < other.Position.Row
+	}
+	return e.Position.Column < other.Position.Column
+}
+
+// swatch converts an entry, skipping ones in color models we do not read
+func (e kplEntry) swatch() (Swatch, bool) {
+	s := Swatch{Name: e.Name}
+	switch {
+	case e.RGB != nil:
+		s.Color = color.FromSpace(color.Values{e.RGB.R, e.RGB.G, e.RGB.B}, color.SRGB)
+	case e.CMYK != nil:
+		s.CMYK = []float64{e.CMYK.C, e.CMYK.M, e.CMYK.Y, e.CMYK.K}
+		s.Color = color.FromCMYK(e.CMYK.C, e.CMYK.M, e.CMYK.Y, e.CMYK.K)
+	case e.Gray != nil:
+		s.Color = color.FromSpace(color.Values{e.Gray.G, e.Gray.G, e.Gray.G}, color.SRGB)
+	case e.Lab != nil:
+		s.Color = color.FromSpace(color.Values{e.Lab.L, e.Lab.A, e.Lab.B}, color.Lab)
+	default:
+		return Swatch{}, false
+	}
+	return s, true
+}
+
+func encodeKPL(w io.Writer, p *Palette) error {
+	columns := p.Columns
+	if columns <= 0 {
+		columns = defaultColumns
+	}
+
+	set := kplColorset{Version: "2.0", Name: p.Name, Columns: columns}
+	groups := make(map[string]*kplGroup)
+	var order []string
+	for _, s := range p.Swatches {
+		entries := &set.Entries
+		if s.Group != "" {
+			g, ok := groups[s.Group]
+			if !ok {
+				g = &kplGroup{Name: s.Group}
+				groups[s.Group] = g
+				order = append(order, s.Group)
+			}
+			entries = &g.Entries
+		}
+
+		i := len(*entries)
+		*entries = append(*entries, kplEntry{
+			Name:     s.Name,
+			ID:       s.Color.ToHex(),
+			BitDepth: "U8",
+			RGB: &kplRGB{
+				Space: kplRGBProfile,
+				R:     float64(s.Color.R) / 255,
+				G:     float64(s.Color.G) / 255,
+				B:     float64(s.Color.B) / 255,
+			},
+			Position: &kplPosition{Row: i / columns, Column: i % columns},
+		})
+	}
+
+	set.Rows = rowsFor(len(set.Entries), columns)
+	for _, name := range order {
+		g := groups[name]
+		g.Rows = rowsFor(len(g.Entries), columns)
+		set.Groups = append(set.Groups, *g)
+	}
+
+	colorset, err := xml.MarshalIndent(set, "", " ")
+	if err != nil {
+		return err
+	}
+
+	archive := zip.NewWriter(w)
+	//Krita expects the mimetype first and uncompressed, like OpenDocument
+	files := []struct {
+		name   string
+		method uint16
+		data   []byte
+	}{
+		{"mimetype", zip.Store, []byte(kplMimeType)},
+		{"colorset.xml", zip.Deflate, append([]byte(xml.Header), colorset...)},
+		{"profiles.xml", zip.Deflate, []byte(xml.Header + "<Profiles/>\n")},
+	}
+	for _, f := range files {
+		fw, err := archive.CreateHeader(&zip.FileHeader{Name: f.name, Method: f.method})
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// rowsFor returns how many grid rows n entries take up
+func rowsFor(n, columns int) int {
+	return (n + columns - 1) / columns
+}
@@ -0,0 +1,31 @@
+package swatches
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestKPLMixedPositionsOrder(t *testing.T) {
+	at := func(name string, row, column int) kplEntry {
+		return kplEntry{Name: name, Position: &kplPosition{Row: row, Column: column}}
+	}
+	loose := func(name string) kplEntry {
+		return kplEntry{Name: name}
+	}
+
+	//Every input order must give the same result
+	inputs := [][]kplEntry{
+		{loose("x"), at("b", 0, 1), loose("y"), at("a", 0, 0), at("c", 1, 0)},
+		{at("c", 1, 0), loose("x"), at("a", 0, 0), loose("y"), at("b", 0, 1)},
+	}
+	for _, entries := range inputs {
+		sort.SliceStable(entries, func(i, j int) bool { return entries[i].before(entries[j]) })
+		var got string
+		for _, e := range entries {
+			got += e.Name
+		}
+		if got != "abcxy" {
+			t.Errorf("got order %q, want \"abcxy\"", got)
+		}
+	}
+}
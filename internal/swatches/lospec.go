@@ -0,0 +1,40 @@
+package swatches
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Lospec hex palettes (.hex): one RRGGBB code per line, without names.
+
+func decodeHexList(r io.Reader) (*Palette, error) {
+	scanner := bufio.NewScanner(r)
+	p := &Palette{}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		col, err := color.NewColorHex("#" + strings.TrimPrefix(line, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFile, line)
+		}
+		p.Swatches = append(p.Swatches, Swatch{Color: col})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func encodeHexList(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	for _, s := range p.Swatches {
+		fmt.Fprintln(bw, strings.TrimPrefix(s.Color.ToHex(), "#"))
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,163 @@
+package swatches
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Office suite palettes: LibreOffice/OpenOffice color tables (.soc) and
+// Scribus color sets (.xml). Scribus keeps CMYK values; LibreOffice is RGB only.
+
+const socHeader = `<ooo:color-table xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" ` +
+	`xmlns:draw="urn:oasis:names:tc:opendocument:xmlns:drawing:1.0" ` +
+	`xmlns:xlink="http://www.w3.org/1999/xlink" xmlns:svg="http://www.w3.org/2000/svg" ` +
+	`xmlns:ooo="http://openoffice.org/2004/office">`
+
+type socTable struct {
+	XMLName xml.Name `xml:"color-table"`
+	Colors  []struct {
+		Name  string `xml:"name,attr"`
+		Color string `xml:"color,attr"`
+	} `xml:"color"`
+}
+
+type scribusColors struct {
+	XMLName xml.Name `xml:"SCRIBUSCOLORS"`
+	Name    string   `xml:"Name,attr"`
+	Colors  []struct {
+		Name  string `xml:"NAME,attr"`
+		RGB   string `xml:"RGB,attr"`
+		CMYK  string `xml:"CMYK,attr"`
+		Space string `xml:"SPACE,attr"`
+		R     string `xml:"R,attr"`
+		G     string `xml:"G,attr"`
+		B     string `xml:"B,attr"`
+		C     string `xml:"C,attr"`
+		M     string `xml:"M,attr"`
+		Y     string `xml:"Y,attr"`
+		K     string `xml:"K,attr"`
+	} `xml:"COLOR"`
+}
+
+func decodeSOC(r io.Reader) (*Palette, error) {
+	var table socTable
+	if err := xml.NewDecoder(r).Decode(&table); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	p := &Palette{}
+	for _, c := range table.Colors {
+		col, err := color.ParseHex(c.Color)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFile, c.Color)
+		}
+		p.Swatches = append(p.Swatches, Swatch{Name: c.Name, Color: col})
+	}
+	return p, nil
+}
+
+func encodeSOC(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, xml.Header, socHeader, "\n")
+	for _, s := range p.Swatches {
+		fmt.Fprintf(bw, "  <draw:color draw:name=\"%s\" draw:color=\"%s\"/>\n", escapeXML(s.label()), s.Color.ToHex())
+	}
+	fmt.Fprint(bw, "</ooo:color-table>\n")
+	return bw.Flush()
+}
+
+func decodeScribus(r io.Reader) (*Palette, error) {
+	var set scribusColors
+	if err := xml.NewDecoder(r).Decode(&set); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	p := &Palette{Name: set.Name}
+	for _, c := range set.Colors {
+		s := Swatch{Name: c.Name}
+		switch {
+		case c.CMYK != "":
+			//"#ccmmyykk" with each channel 0..255
+			v, err := strconv.ParseUint(strings.TrimPrefix(c.CMYK, "#"), 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidFile, c.CMYK)
+			}
+			s.CMYK = []float64{float64(v>>24&0xff) / 255, float64(v>>16&0xff) / 255, float64(v>>8&0xff) / 255, float64(v&0xff) / 255}
+		case c.RGB != "":
+			col, err := color.ParseHex(c.RGB)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidFile, c.RGB)
+			}
+			s.Color = col
+		case c.Space == "CMYK":
+			//Scribus 1.5 writes percentages
+			s.CMYK = make([]float64, 4)
+			for i, text := range []string{c.C, c.M, c.Y, c.K} {
+				v, err := strconv.ParseFloat(text, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: color %q", ErrInvalidFile, c.Name)
+				}
+				s.CMYK[i] = v / 100
+			}
+		case c.Space == "RGB":
+			var rgb [3]uint8
+			for i, text := range []string{c.R, c.G, c.B} {
+				v, err := strconv.ParseFloat(text, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: color %q", ErrInvalidFile, c.Name)
+				}
+				rgb[i] = clampChannel(v)
+			}
+			s.Color = color.NewColor(rgb[0], rgb[1], rgb[2])
+		default:
+			continue //Lab and spot-only entries
+		}
+		if s.Color == nil {
+			s.Color = color.FromCMYK(s.CMYK[0], s.CMYK[1], s.CMYK[2], s.CMYK[3])
+		}
+		p.Swatches = append(p.Swatches, s)
+	}
+	return p, nil
+}
+
+func encodeScribus(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, xml.Header)
+	fmt.Fprintf(bw, "<SCRIBUSCOLORS Name=\"%s\">\n", escapeXML(p.Name))
+	for _, s := range p.Swatches {
+		if len(s.CMYK) == 4 {
+			cmyk := s.cmyk()
+			fmt.Fprintf(bw, " <COLOR NAME=\"%s\" CMYK=\"#%02x%02x%02x%02x\" Spot=\"0\" Register=\"0\"/>\n",
+				escapeXML(s.label()), clampChannel(cmyk[0]*255), clampChannel(cmyk[1]*255), clampChannel(cmyk[2]*255), clampChannel(cmyk[3]*255))
+		} else {
+			fmt.Fprintf(bw, " <COLOR NAME=\"%s\" RGB=\"%s\" Spot=\"0\" Register=\"0\"/>\n", escapeXML(s.label()), s.Color.ToHex())
+		}
+	}
+	fmt.Fprint(bw, "</SCRIBUSCOLORS>\n")
+	return bw.Flush()
+}
+
+// escapeXML escapes text for use in an attribute value
+func escapeXML(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+// clampChannel rounds a 0..255 value to a byte
+func clampChannel(v float64) uint8 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 255:
+		return 255
+	}
+	return uint8(v + 0.5)
+}
@@ -0,0 +1,71 @@
+package swatches
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Paint.NET palettes (.txt): ";" comment lines followed by one AARRGGBB code
+// per line. Paint.NET shows exactly 96 slots, padding short palettes with
+// white and ignoring anything past the 96th color.
+
+const (
+	paintNETMaxColors = 96
+	paintNETNameKey   = "Palette Name:"
+)
+
+func decodePaintNET(r io.Reader) (*Palette, error) {
+	scanner := bufio.NewScanner(r)
+	p := &Palette{}
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if comment, ok := cutPrefix(line, ";"); ok {
+			if name, ok := cutPrefix(comment, paintNETNameKey); ok {
+				p.Name = name
+			}
+			continue
+		}
+
+		//Alpha is dropped; palettes store opaque colors
+		argb, err := strconv.ParseUint(line, 16, 32)
+		if err != nil || len(line) != 8 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidFile, line)
+		}
+		p.Swatches = append(p.Swatches, Swatch{Color: color.NewColor(uint8(argb>>16), uint8(argb>>8), uint8(argb))})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.Swatches) == 0 {
+		return nil, ErrInvalidFile
+	}
+	if len(p.Swatches) > paintNETMaxColors {
+		p.Swatches = p.Swatches[:paintNETMaxColors]
+	}
+	return p, nil
+}
+
+func encodePaintNET(w io.Writer, p *Palette) error {
+	if len(p.Swatches) > paintNETMaxColors {
+		return fmt.Errorf("%w: Paint.NET palettes hold at most %d", ErrTooManyColors, paintNETMaxColors)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "; paint.net Palette File\r\n")
+	if p.Name != "" {
+		fmt.Fprintf(bw, "; %s %s\r\n", paintNETNameKey, p.Name)
+	}
+	fmt.Fprintf(bw, "; Colors: %d\r\n", len(p.Swatches))
+	for _, s := range p.Swatches {
+		fmt.Fprintf(bw, "FF%02X%02X%02X\r\n", s.Color.R, s.Color.G, s.Color.B)
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,107 @@
+package swatches
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// Stylesheet exporters. Each color becomes a variable named after a slug of
+// its name, so "Primary/Button" is exported as "primary-button".
+
+func encodeCSS(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, ":root {")
+	for i, slug := range Slugs(p) {
+		fmt.Fprintf(bw, "  --%s: %s;\n", slug, p.Swatches[i].Color.ToHex())
+	}
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// encodeSCSS writes one variable per color plus a map of all of them for @each loops
+func encodeSCSS(w io.Writer, p *Palette) error {
+	slugs := Slugs(p)
+	bw := bufio.NewWriter(w)
+	for i, slug := range slugs {
+		fmt.Fprintf(bw, "$%s: %s;\n", slug, p.Swatches[i].Color.ToHex())
+	}
+
+	//The map must not shadow one of the color variables
+	mapName := Slug(p.Name, "palette")
+	for _, slug := range slugs {
+		if slug == mapName {
+			mapName += "-colors"
+			break
+		}
+	}
+	fmt.Fprintf(bw, "\n$%s: (\n", mapName)
+	for _, slug := range slugs {
+		fmt.Fprintf(bw, "  \"%s\": $%s,\n", slug, slug)
+	}
+	fmt.Fprintln(bw, ");")
+	return bw.Flush()
+}
+
+func encodeLess(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	for i, slug := range Slugs(p) {
+		fmt.Fprintf(bw, "@%s: %s;\n", slug, p.Swatches[i].Color.ToHex())
+	}
+	return bw.Flush()
+}
+
+func encodeStylus(w io.Writer, p *Palette) error {
+	bw := bufio.NewWriter(w)
+	for i, slug := range Slugs(p) {
+		fmt.Fprintf(bw, "%s = %s\n", slug, p.Swatches[i].Color.ToHex())
+	}
+	return bw.Flush()
+}
+
+// Slug turns a name into a lower case identifier of letters, digits and
+// dashes. Names that produce nothing use fallback, and slugs starting with a
+// digit are prefixed with "color-" since most languages reject them.
+func Slug(name, fallback string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			dash = false
+		} else {
+			dash = true
+		}
+	}
+
+	slug := b.String()
+	if slug == "" {
+		slug = fallback
+	}
+	if slug != "" && unicode.IsDigit(rune(slug[0])) {
+		slug = "color-" + slug
+	}
+	return slug
+}
+
+// Slugs returns a unique slug for every swatch, in order. Unnamed colors use
+// their hex code and clashes get a numeric suffix.
+func Slugs(p *Palette) []string {
+	slugs := make([]string, len(p.Swatches))
+	taken := make(map[string]bool)
+	for i, s := range p.Swatches {
+		base := Slug(s.Name, strings.TrimPrefix(s.Color.ToHex(), "#"))
+		slug := base
+		for n := 2; taken[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+		taken[slug] = true
+		slugs[i] = slug
+	}
+	return slugs
+}
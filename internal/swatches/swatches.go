@@ -0,0 +1,193 @@
+package swatches
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Errors shared by the file formats
+var (
+	ErrUnknownFormat = errors.New("unknown palette file format")
+	ErrInvalidFile   = errors.New("invalid palette file")
+	ErrTooManyColors = errors.New("too many colors for this format")
+)
+
+// Swatch is one named color in a palette file
+type Swatch struct {
+	Name  string
+	Color *color.Color
+	Group string
+
+	// Description is free text carried by formats such as design tokens
+	Description string
+
+	// CMYK holds the print values (0..1) when the file has them, nil otherwise
+	CMYK []float64
+}
+
+// Palette is the format independent content of a palette file
+type Palette struct {
+	Name     string
+	Columns  int //Layout hint used by some editors, 0 when unknown
+	Swatches []Swatch
+}
+
+// Format reads and writes one palette file format. Decode or Encode is nil
+// when the format is export or import only.
+type Format struct {
+	Name       string
+	Extensions []string //Lower case, including the dot
+	Decode     func(r io.Reader) (*Palette, error)
+	Encode     func(w io.Writer, p *Palette) error
+}
+
+// Formats lists every supported file format in menu order. Import tries
+// formats sharing an extension in this order.
+var Formats = []*Format{
+	{Name: "Adobe Swatch Exchange", Extensions: []string{".ase"}, Decode: decodeASE, Encode: encodeASE},
+	{Name: "Photoshop Swatches", Extensions: []string{".aco"}, Decode: decodeACO, Encode: encodeACO},
+	{Name: "GIMP Palette", Extensions: []string{".gpl"}, Decode: decodeGPL, Encode: encodeGPL},
+	{Name: "Krita Palette", Extensions: []string{".kpl"}, Decode: decodeKPL, Encode: encodeKPL},
+	{Name: "Lospec Hex", Extensions: []string{".hex"}, Decode: decodeHexList, Encode: encodeHexList},
+	{Name: "JASC Palette", Extensions: []string{".pal"}, Decode: decodeJASC, Encode: encodeJASC},
+	{Name: "Paint.NET Palette", Extensions: []string{".txt"}, Decode: decodePaintNET, Encode: encodePaintNET},
+	{Name: "LibreOffice Colors", Extensions: []string{".soc"}, Decode: decodeSOC, Encode: encodeSOC},
+	{Name: "Scribus Colors", Extensions: []string{".xml"}, Decode: decodeScribus, Encode: encodeScribus},
+	{Name: "Design Tokens", Extensions: []string{".json"}, Decode: decodeTokens, Encode: encodeTokens},
+	{Name: "CSS Custom Properties", Extensions: []string{".css"}, Encode: encodeCSS},
+	{Name: "SCSS", Extensions: []string{".scss"}, Encode: encodeSCSS},
+	{Name: "Less", Extensions: []string{".less"}, Encode: encodeLess},
+	{Name: "Stylus", Extensions: []string{".styl"}, Encode: encodeStylus},
+	{Name: "Tailwind Config (JS)", Extensions: []string{".js"}, Encode: tailwindEncoder(false, false)},
+	{Name: "Tailwind Config (JSON)", Extensions: []string{".json"}, Encode: tailwindEncoder(true, false)},
+	{Name: "Tailwind Config with Shades (JS)", Extensions: []string{".js"}, Encode: tailwindEncoder(false, true)},
+	{Name: "Tailwind Config with Shades (JSON)", Extensions: []string{".json"}, Encode: tailwindEncoder(true, true)},
+	{Name: "Go Package", Extensions: []string{".go"}, Encode: encodeGoFormat},
+}
+
+// Importers returns the formats that can be read
+func Importers() []*Format {
+	var out []*Format
+	for _, f := range Formats {
+		if f.Decode != nil {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// Exporters returns the formats that can be written
+func Exporters() []*Format {
+	var out []*Format
+	for _, f := range Formats {
+		if f.Encode != nil {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// ImportExtensions lists every extension that can be imported
+func ImportExtensions() []string {
+	var exts []string
+	seen := make(map[string]bool)
+	for _, f := range Importers() {
+		for _, ext := range f.Extensions {
+			if !seen[ext] {
+				seen[ext] = true
+				exts = append(exts, ext)
+			}
+		}
+	}
+	return exts
+}
+
+// Decode reads a palette, picking the format from the file name. When several
+// formats share the extension each is tried in turn.
+func Decode(filename string, r io.ReadSeeker) (*Palette, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	var lastErr error = ErrUnknownFormat
+	for _, f := range Importers() {
+		if !hasExtension(f, ext) {
+			continue
+		}
+		if _, err := r.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		p, err := f.Decode(r)
+		if err == nil {
+			if p.Name == "" {
+				p.Name = strings.TrimSuffix(filepath.Base(filename), filepath.Ext(filename))
+			}
+			return p, nil
+		}
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+func hasExtension(f *Format, ext string) bool {
+	for _, e := range f.Extensions {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// FromNamed converts a saved palette for export
+func FromNamed(n *color.NamedPalette) *Palette {
+	p := &Palette{Name: n.Name, Columns: n.Columns}
+	for _, saved := range n.Colors {
+		col, err := color.NewColorHex(saved.Hex)
+		if err != nil {
+			continue
+		}
+		p.Swatches = append(p.Swatches, Swatch{Name: saved.Name, Color: col, Group: saved.Group, Description: saved.Notes, CMYK: saved.CMYK})
+	}
+	return p
+}
+
+// ToNamed converts an imported palette into saved colors. Group names are
+// kept for export and also become tags.
+func (p *Palette) ToNamed() *color.NamedPalette {
+	n := &color.NamedPalette{Name: p.Name, Columns: p.Columns, Colors: make([]*color.SavedColor, 0, len(p.Swatches))}
+	for _, s := range p.Swatches {
+		hex := s.Color.ToHex()
+		if n.Find(hex) != nil {
+			continue
+		}
+
+		saved := color.NewSavedColor(hex, color.SourceImport)
+		saved.Name = s.Name
+		saved.Notes = s.Description
+		saved.CMYK = s.CMYK
+		saved.Group = s.Group
+		if s.Group != "" {
+			saved.Tags = []string{s.Group}
+		}
+		n.Colors = append(n.Colors, saved)
+	}
+	return n
+}
+
+// label returns the swatch name, falling back to the hex code
+func (s Swatch) label() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	return s.Color.ToHex()
+}
+
+// cmyk returns the stored print values or a naive conversion
+func (s Swatch) cmyk() [4]float64 {
+	if len(s.CMYK) == 4 {
+		return [4]float64{s.CMYK[0], s.CMYK[1], s.CMYK[2], s.CMYK[3]}
+	}
+	return s.Color.ToCMYK()
+}
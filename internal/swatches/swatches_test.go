@@ -0,0 +1,54 @@
+package swatches
+
+import (
+	"bytes"
+	"testing"
+
+	"ladle-color-picker/internal/color"
+)
+
+func testPalette() *Palette {
+	return &Palette{
+		Name: "Brand",
+		Swatches: []Swatch{
+			{Name: "Primary", Color: color.NewColor(0xe6, 0x39, 0x46)},
+			{Name: "Ink", Color: color.NewColor(0x1d, 0x35, 0x57)},
+			{Name: "Paper", Color: color.NewColor(0xf1, 0xfa, 0xee)},
+		},
+	}
+}
+
+// TestRoundTrip writes the test palette in every format that can also be
+// read back and checks the colors survive
+func TestRoundTrip(t *testing.T) {
+	want := testPalette()
+	for _, f := range Formats {
+		if f.Decode == nil || f.Encode == nil {
+			continue
+		}
+		t.Run(f.Name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := f.Encode(&buf, want); err != nil {
+				t.Fatalf("encode: %v", err)
+			}
+			got, err := f.Decode(bytes.NewReader(buf.Bytes()))
+			if err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(got.Swatches) != len(want.Swatches) {
+				t.Fatalf("got %d swatches, want %d", len(got.Swatches), len(want.Swatches))
+			}
+			for i, s := range got.Swatches {
+				if s.Color.ToHex() != want.Swatches[i].Color.ToHex() {
+					t.Errorf("swatch %d: got %s, want %s", i, s.Color.ToHex(), want.Swatches[i].Color.ToHex())
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeUnknownExtension(t *testing.T) {
+	if _, err := Decode("colors.doc", bytes.NewReader(nil)); err != ErrUnknownFormat {
+		t.Errorf("got %v, want ErrUnknownFormat", err)
+	}
+}
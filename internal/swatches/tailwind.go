@@ -0,0 +1,133 @@
+package swatches
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Tailwind CSS exporters writing a theme.extend.colors object, either as a
+// tailwind.config.js module or as JSON. The shade variants expand every color
+// into Tailwind's 50–950 scale with the original as DEFAULT.
+
+// TailwindShades are Tailwind's shade steps and the OKLCH lightness used for each
+var TailwindShades = []struct {
+	Step      int
+	Lightness float64
+}{
+	{50, 0.97}, {100, 0.94}, {200, 0.88}, {300, 0.81}, {400, 0.72}, {500, 0.63},
+	{600, 0.55}, {700, 0.47}, {800, 0.40}, {900, 0.33}, {950, 0.25},
+}
+
+// jsKey matches object keys that need no quotes in JavaScript
+var jsKey = regexp.MustCompile(`^([A-Za-z_$][A-Za-z0-9_$]*|[1-9][0-9]*)$`)
+
+// tailwindColor is one entry of the colors object: a plain value or a shade map
+type tailwindColor struct {
+	Key    string
+	Value  string
+	Shades []tailwindShade
+}
+
+type tailwindShade struct {
+	Key   string
+	Value string
+}
+
+func tailwindColors(p *Palette, shades bool) []tailwindColor {
+	lightness := make([]float64, len(TailwindShades))
+	for i, s := range TailwindShades {
+		lightness[i] = s.Lightness
+	}
+
+	colors := make([]tailwindColor, len(p.Swatches))
+	for i, slug := range Slugs(p) {
+		s := p.Swatches[i]
+		colors[i] = tailwindColor{Key: slug, Value: s.Color.ToHex()}
+		if !shades {
+			continue
+		}
+
+		for j, shade := range color.Ramp(s.Color, lightness) {
+			colors[i].Shades = append(colors[i].Shades, tailwindShade{fmt.Sprint(TailwindShades[j].Step), shade.ToHex()})
+		}
+		colors[i].Shades = append(colors[i].Shades, tailwindShade{"DEFAULT", s.Color.ToHex()})
+	}
+	return colors
+}
+
+func tailwindEncoder(asJSON, shades bool) func(io.Writer, *Palette) error {
+	return func(w io.Writer, p *Palette) error {
+		colors := tailwindColors(p, shades)
+		if asJSON {
+			return encodeTailwindJSON(w, colors)
+		}
+		return encodeTailwindJS(w, colors)
+	}
+}
+
+func encodeTailwindJS(w io.Writer, colors []tailwindColor) error {
+	key := func(k string) string {
+		if jsKey.MatchString(k) {
+			return k
+		}
+		return "'" + k + "'"
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "/** @type {import('tailwindcss').Config} */\n")
+	fmt.Fprint(bw, "module.exports = {\n  theme: {\n    extend: {\n      colors: {\n")
+	for _, c := range colors {
+		if c.Shades == nil {
+			fmt.Fprintf(bw, "        %s: '%s',\n", key(c.Key), c.Value)
+			continue
+		}
+		fmt.Fprintf(bw, "        %s: {\n", key(c.Key))
+		for _, s := range c.Shades {
+			fmt.Fprintf(bw, "          %s: '%s',\n", key(s.Key), s.Value)
+		}
+		fmt.Fprint(bw, "        },\n")
+	}
+	fmt.Fprint(bw, "      },\n    },\n  },\n}\n")
+	return bw.Flush()
+}
+
+func encodeTailwindJSON(w io.Writer, colors []tailwindColor) error {
+	var buf bytes.Buffer
+	buf.WriteString(`{"theme":{"extend":{"colors":{`)
+	for i, c := range colors {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSON(&buf, c.Key)
+		buf.WriteByte(':')
+		if c.Shades == nil {
+			writeJSON(&buf, c.Value)
+			continue
+		}
+		buf.WriteByte('{')
+		for j, s := range c.Shades {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			writeJSON(&buf, s.Key)
+			buf.WriteByte(':')
+			writeJSON(&buf, s.Value)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteString(`}}}}`)
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err := w.Write(out.Bytes())
+	return err
+}
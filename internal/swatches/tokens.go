@@ -0,0 +1,306 @@
+package swatches
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// W3C Design Tokens Community Group files (.json). Tokens are objects with a
+// $value, nested in groups; $type may be set on a token or inherited from an
+// enclosing group, and a value of "{group.token}" is an alias to another token.
+// Imported names are the token path joined with "/", and exported names are
+// split on "/" back into groups.
+
+const tokenPathSeparator = "/"
+
+// member is one key of a JSON object, kept in file order
+type member struct {
+	Key   string
+	Value json.RawMessage
+}
+
+// designToken is a token found while walking the groups
+type designToken struct {
+	path        []string
+	tokenType   string
+	value       json.RawMessage
+	description string
+}
+
+func decodeTokens(r io.Reader) (*Palette, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var tokens []*designToken
+	byPath := make(map[string]*designToken)
+	var walk func(raw json.RawMessage, path []string, inherited string) error
+	walk = func(raw json.RawMessage, path []string, inherited string) error {
+		members, err := objectMembers(raw)
+		if err != nil {
+			return err
+		}
+
+		tokenType, description := inherited, ""
+		var value json.RawMessage
+		for _, m := range members {
+			switch m.Key {
+			case "$type":
+				json.Unmarshal(m.Value, &tokenType)
+			case "$description":
+				json.Unmarshal(m.Value, &description)
+			case "$value":
+				value = m.Value
+			}
+		}
+
+		if value != nil {
+			t := &designToken{path: path, tokenType: tokenType, value: value, description: description}
+			tokens = append(tokens, t)
+			byPath[strings.Join(path, ".")] = t
+			return nil
+		}
+
+		for _, m := range members {
+			if strings.HasPrefix(m.Key, "$") || !bytes.HasPrefix(bytes.TrimSpace(m.Value), []byte("{")) {
+				continue
+			}
+			child := append(append([]string(nil), path...), m.Key)
+			if err := walk(m.Value, child, tokenType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(data, nil, ""); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+
+	p := &Palette{}
+	for _, t := range tokens {
+		target, err := resolveAlias(t, byPath)
+		if err != nil {
+			return nil, err
+		}
+		//An alias takes its type from the token it points to when it has none of its own
+		tokenType := t.tokenType
+		if tokenType == "" {
+			tokenType = target.tokenType
+		}
+		if tokenType != "" && tokenType != "color" {
+			continue
+		}
+
+		col, err := tokenColor(target.value)
+		if err != nil {
+			if tokenType == "" {
+				continue //Untyped and not a color
+			}
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidFile, strings.Join(t.path, "."), err)
+		}
+		p.Swatches = append(p.Swatches, Swatch{
+			Name:        strings.Join(t.path, tokenPathSeparator),
+			Color:       col,
+			Description: t.description,
+		})
+	}
+	if len(p.Swatches) == 0 {
+		return nil, fmt.Errorf("%w: no color tokens", ErrInvalidFile)
+	}
+	return p, nil
+}
+
+// resolveAlias follows "{a.b}" references until it reaches a token with a literal value
+func resolveAlias(t *designToken, byPath map[string]*designToken) (*designToken, error) {
+	seen := make(map[*designToken]bool)
+	for {
+		var ref string
+		if json.Unmarshal(t.value, &ref) != nil || !strings.HasPrefix(ref, "{") || !strings.HasSuffix(ref, "}") {
+			return t, nil
+		}
+		if seen[t] {
+			return nil, fmt.Errorf("%w: circular alias %s", ErrInvalidFile, ref)
+		}
+		seen[t] = true
+
+		target, ok := byPath[ref[1:len(ref)-1]]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown alias %s", ErrInvalidFile, ref)
+		}
+		t = target
+	}
+}
+
+// tokenColor reads a hex string, a CSS color() string or a color object with
+// colorSpace and components
+func tokenColor(raw json.RawMessage) (*color.Color, error) {
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		if strings.HasPrefix(text, "#") {
+			hex := strings.TrimPrefix(text, "#")
+			//Alpha is dropped; saved colors are opaque
+			switch len(hex) {
+			case 4:
+				hex = hex[:3]
+			case 8:
+				hex = hex[:6]
+			}
+			return color.ParseHex(hex)
+		}
+		wide, err := color.ParseCSSColor(text)
+		if err != nil {
+			return nil, err
+		}
+		return wide.ToColor(), nil
+	}
+
+	var obj struct {
+		ColorSpace string    `json:"colorSpace"`
+		Components []float64 `json:"components"`
+		Hex        string    `json:"hex"`
+	}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+	if obj.Hex != "" {
+		return color.ParseHex(obj.Hex)
+	}
+	space, ok := color.RGBSpaceByCSSName(obj.ColorSpace)
+	if !ok || len(obj.Components) != 3 {
+		return nil, fmt.Errorf("unsupported color value %s", raw)
+	}
+	return color.NewWideColor(space, obj.Components[0], obj.Components[1], obj.Components[2]).ToColor(), nil
+}
+
+// objectMembers decodes a JSON object keeping its keys in order
+func objectMembers(raw json.RawMessage) ([]member, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("expected an object")
+	}
+
+	var members []member
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var m member
+		m.Key, _ = tok.(string)
+		if err := dec.Decode(&m.Value); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+// tokenGroup is an export group; children keep insertion order
+type tokenGroup struct {
+	keys   []string
+	groups map[string]*tokenGroup
+	tokens map[string]Swatch
+}
+
+func newTokenGroup() *tokenGroup {
+	return &tokenGroup{groups: make(map[string]*tokenGroup), tokens: make(map[string]Swatch)}
+}
+
+func encodeTokens(w io.Writer, p *Palette) error {
+	root := newTokenGroup()
+	for _, s := range p.Swatches {
+		path := tokenPath(s)
+		g := root
+		for _, key := range path[:len(path)-1] {
+			child, ok := g.groups[key]
+			if !ok {
+				if _, clash := g.tokens[key]; clash {
+					return fmt.Errorf("token %q is also used as a group", strings.Join(path, tokenPathSeparator))
+				}
+				child = newTokenGroup()
+				g.groups[key] = child
+				g.keys = append(g.keys, key)
+			}
+			g = child
+		}
+
+		//Keys must be unique within a group; later duplicates get a number
+		key := path[len(path)-1]
+		unique := key
+		for i := 2; g.has(unique); i++ {
+			unique = fmt.Sprintf("%s-%d", key, i)
+		}
+		g.tokens[unique] = s
+		g.keys = append(g.keys, unique)
+	}
+
+	var buf bytes.Buffer
+	root.write(&buf)
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	out.WriteByte('\n')
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+func (g *tokenGroup) has(key string) bool {
+	_, isGroup := g.groups[key]
+	_, isToken := g.tokens[key]
+	return isGroup || isToken
+}
+
+func (g *tokenGroup) write(buf *bytes.Buffer) {
+	buf.WriteByte('{')
+	for i, key := range g.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		writeJSON(buf, key)
+		buf.WriteByte(':')
+
+		if child, ok := g.groups[key]; ok {
+			child.write(buf)
+			continue
+		}
+
+		s := g.tokens[key]
+		buf.WriteString(`{"$type":"color","$value":`)
+		writeJSON(buf, s.Color.ToHex())
+		if s.Description != "" {
+			buf.WriteString(`,"$description":`)
+			writeJSON(buf, s.Description)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte('}')
+}
+
+// tokenPath splits a swatch name into group keys and a token key. Token names
+// may not contain dots or braces or start with "$", so those are replaced.
+func tokenPath(s Swatch) []string {
+	var path []string
+	for _, part := range strings.Split(s.label(), tokenPathSeparator) {
+		part = strings.TrimSpace(strings.NewReplacer(".", "-", "{", "", "}", "").Replace(part))
+		part = strings.TrimLeft(part, "$")
+		if part != "" {
+			path = append(path, part)
+		}
+	}
+	if len(path) == 0 {
+		path = []string{strings.TrimPrefix(s.Color.ToHex(), "#")}
+	}
+	return path
+}
+
+func writeJSON(buf *bytes.Buffer, v string) {
+	data, _ := json.Marshal(v)
+	buf.Write(data)
+}
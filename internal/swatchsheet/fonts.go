@@ -0,0 +1,72 @@
+package swatchsheet
+
+import (
+	"math"
+	"sync"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+)
+
+// The Go fonts ship with the module, so PNG sheets look the same everywhere
+// and SVG sheets can be laid out with the same metrics
+const fontFamily = "'Go', 'Helvetica Neue', Arial, sans-serif"
+
+// style is a font weight and size in points
+type style struct {
+	bold bool
+	size float64
+}
+
+type faceKey struct {
+	bold bool
+	size float64
+}
+
+var (
+	fontsOnce   sync.Once
+	regularFont *opentype.Font
+	boldFont    *opentype.Font
+
+	facesMu sync.Mutex
+	faces   = map[faceKey]font.Face{}
+)
+
+// face returns the style's font face at the given scale
+func (st style) face(scale float64) font.Face {
+	fontsOnce.Do(func() {
+		//The embedded fonts are known to parse
+		regularFont, _ = opentype.Parse(goregular.TTF)
+		boldFont, _ = opentype.Parse(gobold.TTF)
+	})
+
+	key := faceKey{st.bold, st.size * scale}
+	facesMu.Lock()
+	defer facesMu.Unlock()
+	if f, ok := faces[key]; ok {
+		return f
+	}
+
+	src := regularFont
+	if st.bold {
+		src = boldFont
+	}
+	f, _ := opentype.NewFace(src, &opentype.FaceOptions{Size: key.size, DPI: 72, Hinting: font.HintingNone})
+	faces[key] = f
+	return f
+}
+
+// measure returns the width of the text in points
+func (st style) measure(text string) int {
+	return font.MeasureString(st.face(1), text).Ceil()
+}
+
+func (st style) lineHeight() int {
+	return int(math.Ceil(st.size * lineHeight))
+}
+
+func (st style) descent() int {
+	return st.face(1).Metrics().Descent.Ceil()
+}
@@ -0,0 +1,59 @@
+package swatchsheet
+
+import (
+	"image"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/math/fixed"
+
+	"ladle-color-picker/internal/color"
+)
+
+func encodePNG(w io.Writer, p *color.NamedPalette, opts Options) error {
+	s, err := layout(p, opts)
+	if err != nil {
+		return err
+	}
+
+	scale := opts.Scale
+	if scale <= 0 {
+		scale = 1
+	}
+	px := func(v int) int {
+		return int(math.Round(float64(v) * scale))
+	}
+
+	if float64(px(s.width))*float64(px(s.height)) > maxPixels {
+		return ErrTooLarge
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, px(s.width), px(s.height)))
+	draw.Draw(img, img.Bounds(), image.NewUniform(sheetBackground.ToFyneColor()), image.Point{}, draw.Src)
+
+	drawText := func(line textLine) {
+		d := &font.Drawer{
+			Dst:  img,
+			Src:  image.NewUniform(line.fill.ToFyneColor()),
+			Face: line.style.face(scale),
+			Dot:  fixed.P(px(line.x), px(line.y)),
+		}
+		d.DrawString(line.text)
+	}
+
+	if s.title != nil {
+		drawText(*s.title)
+	}
+	for _, c := range s.cards {
+		rect := image.Rect(px(c.x), px(c.y), px(c.x+c.w), px(c.y+c.h))
+		draw.Draw(img, rect, image.NewUniform(c.fill.ToFyneColor()), image.Point{}, draw.Src)
+		for _, line := range c.lines {
+			drawText(line)
+		}
+	}
+
+	return png.Encode(w, img)
+}
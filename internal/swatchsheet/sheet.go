@@ -0,0 +1,264 @@
+// Package swatchsheet renders a palette as an image of labelled swatch
+// cards, for sharing in chats and documents
+package swatchsheet
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Sheet errors
+var (
+	ErrEmpty    = errors.New("the palette has no colors")
+	ErrTooLarge = errors.New("the sheet is too large for a PNG, use a smaller scale or SVG")
+)
+
+// Layout arranges the swatch cards on the sheet
+type Layout int
+
+const (
+	// Grid wraps square cards into rows
+	Grid Layout = iota
+	// Strip puts tall cards side by side without gaps, wrapping long palettes
+	Strip
+)
+
+// Layouts lists every layout in menu order
+var Layouts = []Layout{Grid, Strip}
+
+func (l Layout) String() string {
+	switch l {
+	case Strip:
+		return "Strip"
+	default:
+		return "Grid"
+	}
+}
+
+// Options controls what the sheet shows and how it is arranged
+type Options struct {
+	Layout  Layout
+	Columns int  //Grid only, 0 uses the palette's hint or a near-square grid
+	Title   bool //Palette name above the cards
+	Names   bool //Color names above the hex codes
+
+	// Formats are names of color formatters shown under the hex code
+	Formats []string
+
+	// Scale multiplies the pixel size of PNG sheets, 0 means 1
+	Scale float64
+}
+
+// DefaultOptions returns a titled grid with names and hex codes
+func DefaultOptions() Options {
+	return Options{Layout: Grid, Title: true, Names: true}
+}
+
+// Exporter writes a sheet in one image format
+type Exporter struct {
+	Name      string
+	Extension string //Including the dot
+	Encode    func(w io.Writer, p *color.NamedPalette, opts Options) error
+}
+
+// Exporters lists every supported image format in menu order
+var Exporters = []*Exporter{
+	{Name: "PNG Image", Extension: ".png", Encode: encodePNG},
+	{Name: "SVG Image", Extension: ".svg", Encode: encodeSVG},
+}
+
+// FileName suggests a file name for the palette's sheet
+func (e *Exporter) FileName(p *color.NamedPalette) string {
+	return p.Name + e.Extension
+}
+
+// Sizes in points; PNG sheets multiply them by the scale
+const (
+	margin      = 24
+	cardPadding = 12
+	gridGap     = 12
+	gridWidth   = 180
+	gridHeight  = 150
+	stripWidth  = 130
+	stripHeight = 260
+
+	stripMaxColumns = 12
+
+	// maxPixels caps PNG sheets at about 160 MB of RGBA
+	maxPixels = 40_000_000
+
+	titleSize = 22
+	nameSize  = 15
+	valueSize = 12
+
+	lineHeight = 1.35 //Times the font size
+)
+
+var (
+	sheetBackground = color.NewColor(255, 255, 255)
+	titleColor      = color.NewColor(0x1f, 0x1f, 0x1f)
+)
+
+// sheet is a laid out page shared by the renderers
+type sheet struct {
+	width, height int
+	title         *textLine
+	cards         []card
+}
+
+type card struct {
+	x, y, w, h int
+	fill       *color.Color
+	lines      []textLine
+}
+
+// textLine is a run of text with its baseline origin
+type textLine struct {
+	x, y  int
+	text  string
+	style style
+	fill  *color.Color
+}
+
+// layout places the palette's cards and labels
+func layout(p *color.NamedPalette, opts Options) (*sheet, error) {
+	var colors []*color.SavedColor
+	for _, saved := range p.Colors {
+		if _, err := color.NewColorHex(saved.Hex); err == nil {
+			colors = append(colors, saved)
+		}
+	}
+	if len(colors) == 0 {
+		return nil, ErrEmpty
+	}
+
+	//Strips sit edge to edge, with a gap only between wrapped rows
+	columns, cardW, minH, gap, rowGap := len(colors), stripWidth, stripHeight, 0, gridGap
+	if columns > stripMaxColumns {
+		columns = stripMaxColumns
+	}
+	if opts.Layout == Grid {
+		columns, cardW, minH, gap = gridColumns(len(colors), opts.Columns, p.Columns), gridWidth, gridHeight, gridGap
+	}
+	rows := (len(colors) + columns - 1) / columns
+
+	var formatters []*color.Formatter
+	for _, name := range opts.Formats {
+		if f, ok := color.FormatterByName(name); ok {
+			formatters = append(formatters, f)
+		}
+	}
+
+	s := &sheet{}
+	top := margin
+	if opts.Title && strings.TrimSpace(p.Name) != "" {
+		titleStyle := style{bold: true, size: titleSize}
+		s.title = &textLine{
+			x: margin, y: margin + titleSize,
+			text:  fit(p.Name, titleStyle, columns*cardW+(columns-1)*gap),
+			style: titleStyle,
+			fill:  titleColor,
+		}
+		top += int(math.Ceil(titleSize * 1.8))
+	}
+
+	//Every card gets the height of the tallest label block so rows line up
+	labels := make([][]textLine, len(colors))
+	cardH := minH
+	for i, saved := range colors {
+		labels[i] = cardLabels(saved, opts.Names, formatters, cardW-2*cardPadding)
+		if h := blockHeight(labels[i]) + 2*cardPadding; h > cardH {
+			cardH = h
+		}
+	}
+
+	for i, saved := range colors {
+		fill, _ := color.NewColorHex(saved.Hex)
+		c := card{
+			x:    margin + (i%columns)*(cardW+gap),
+			y:    top + (i/columns)*(cardH+rowGap),
+			w:    cardW,
+			h:    cardH,
+			fill: fill,
+		}
+
+		//Labels sit in the bottom left corner, leaving the top of the card plain
+		ink := fill.ReadableText()
+		baseline := c.y + c.h - cardPadding - blockHeight(labels[i])
+		for _, line := range labels[i] {
+			baseline += line.style.lineHeight()
+			line.x, line.y, line.fill = c.x+cardPadding, baseline-line.style.descent(), ink
+			c.lines = append(c.lines, line)
+		}
+		s.cards = append(s.cards, c)
+	}
+
+	s.width = 2*margin + columns*cardW + (columns-1)*gap
+	s.height = top + rows*cardH + (rows-1)*rowGap + margin
+	return s, nil
+}
+
+// cardLabels lists the name, hex code and extra formats for one color
+func cardLabels(saved *color.SavedColor, names bool, formatters []*color.Formatter, width int) []textLine {
+	c, _ := color.NewColorHex(saved.Hex)
+	nameStyle := style{bold: true, size: nameSize}
+	valueStyle := style{size: valueSize}
+
+	var lines []textLine
+	if names && saved.Name != "" {
+		lines = append(lines, textLine{text: fit(saved.Name, nameStyle, width), style: nameStyle})
+	}
+	hexStyle := valueStyle
+	if len(lines) == 0 {
+		hexStyle = nameStyle
+	}
+	lines = append(lines, textLine{text: fit(strings.ToUpper(c.ToHex()), hexStyle, width), style: hexStyle})
+	for _, f := range formatters {
+		lines = append(lines, textLine{text: fit(f.Format(c), valueStyle, width), style: valueStyle})
+	}
+	return lines
+}
+
+func blockHeight(lines []textLine) int {
+	h := 0
+	for _, line := range lines {
+		h += line.style.lineHeight()
+	}
+	return h
+}
+
+// gridColumns picks the column count: the requested one, the palette's own
+// hint, or the smallest count giving a grid at least as wide as it is tall
+func gridColumns(n, requested, hint int) int {
+	columns := requested
+	if columns <= 0 {
+		columns = hint
+	}
+	if columns <= 0 {
+		columns = int(math.Ceil(math.Sqrt(float64(n))))
+	}
+	if columns > n {
+		columns = n
+	}
+	return columns
+}
+
+// fit shortens text with an ellipsis until it fits the width
+func fit(text string, st style, width int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if st.measure(text) <= width {
+		return text
+	}
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		if short := strings.TrimSpace(string(runes)) + "…"; st.measure(short) <= width {
+			return short
+		}
+	}
+	return ""
+}
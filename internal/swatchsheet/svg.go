@@ -0,0 +1,51 @@
+package swatchsheet
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"ladle-color-picker/internal/color"
+)
+
+func encodeSVG(w io.Writer, p *color.NamedPalette, opts Options) error {
+	s, err := layout(p, opts)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		s.width, s.height, s.width, s.height)
+	if p.Name != "" {
+		bw.WriteString("  <title>")
+		xml.EscapeText(bw, []byte(p.Name))
+		bw.WriteString("</title>\n")
+	}
+	fmt.Fprintf(bw, "  <rect width=\"100%%\" height=\"100%%\" fill=\"%s\"/>\n", sheetBackground.ToHex())
+	fmt.Fprintf(bw, "  <g font-family=\"%s\">\n", fontFamily)
+
+	if s.title != nil {
+		writeSVGText(bw, *s.title)
+	}
+	for _, c := range s.cards {
+		fmt.Fprintf(bw, "    <rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"/>\n", c.x, c.y, c.w, c.h, c.fill.ToHex())
+		for _, line := range c.lines {
+			writeSVGText(bw, line)
+		}
+	}
+
+	bw.WriteString("  </g>\n</svg>\n")
+	return bw.Flush()
+}
+
+func writeSVGText(w *bufio.Writer, line textLine) {
+	weight := ""
+	if line.style.bold {
+		weight = ` font-weight="bold"`
+	}
+	fmt.Fprintf(w, "    <text x=\"%d\" y=\"%d\" font-size=\"%g\"%s fill=\"%s\">", line.x, line.y, line.style.size, weight, line.fill.ToHex())
+	xml.EscapeText(w, []byte(line.text))
+	w.WriteString("</text>\n")
+}
@@ -0,0 +1,36 @@
+// Package terminal writes terminal color themes in the config formats of
+// common terminal emulators
+package terminal
+
+import (
+	"io"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Exporter writes a theme in one terminal emulator's format
+type Exporter struct {
+	Name      string
+	Extension string //Including the dot
+	Encode    func(w io.Writer, t *color.TerminalTheme) error
+}
+
+// Exporters lists every supported terminal emulator in menu order
+var Exporters = []*Exporter{
+	{Name: "Alacritty", Extension: ".toml", Encode: encodeAlacritty},
+	{Name: "kitty", Extension: ".conf", Encode: encodeKitty},
+	{Name: "Xresources", Extension: ".Xresources", Encode: encodeXresources},
+	{Name: "Windows Terminal", Extension: ".json", Encode: encodeWindowsTerminal},
+	{Name: "iTerm2", Extension: ".itermcolors", Encode: encodeITerm},
+	{Name: "foot", Extension: ".ini", Encode: encodeFoot},
+	{Name: "WezTerm", Extension: ".toml", Encode: encodeWezTerm},
+}
+
+// ansiNames are the lower case color names used as keys by several formats
+var ansiNames = []string{"black", "red", "green", "yellow", "blue", "magenta", "cyan", "white"}
+
+// bare returns a hex code without the leading #
+func bare(hex string) string {
+	return strings.TrimPrefix(hex, "#")
+}
@@ -0,0 +1,38 @@
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"ladle-color-picker/internal/color"
+)
+
+// encodeITerm writes an .itermcolors property list
+func encodeITerm(w io.Writer, t *color.TerminalTheme) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+`)
+	for i := range t.ANSI {
+		writeITermColor(bw, fmt.Sprintf("Ansi %d Color", i), t.Slot(i))
+	}
+	writeITermColor(bw, "Background Color", t.Slot(color.SlotBackground))
+	writeITermColor(bw, "Foreground Color", t.Slot(color.SlotForeground))
+	writeITermColor(bw, "Cursor Color", t.Slot(color.SlotCursor))
+	writeITermColor(bw, "Cursor Text Color", t.Slot(color.SlotBackground))
+	fmt.Fprint(bw, "</dict>\n</plist>\n")
+	return bw.Flush()
+}
+
+func writeITermColor(w io.Writer, key string, c *color.Color) {
+	fmt.Fprintf(w, "\t<key>%s</key>\n\t<dict>\n", key)
+	fmt.Fprint(w, "\t\t<key>Alpha Component</key>\n\t\t<real>1</real>\n")
+	fmt.Fprintf(w, "\t\t<key>Blue Component</key>\n\t\t<real>%.6f</real>\n", float64(c.B)/255)
+	fmt.Fprint(w, "\t\t<key>Color Space</key>\n\t\t<string>sRGB</string>\n")
+	fmt.Fprintf(w, "\t\t<key>Green Component</key>\n\t\t<real>%.6f</real>\n", float64(c.G)/255)
+	fmt.Fprintf(w, "\t\t<key>Red Component</key>\n\t\t<real>%.6f</real>\n", float64(c.R)/255)
+	fmt.Fprint(w, "\t</dict>\n")
+}
@@ -0,0 +1,54 @@
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"ladle-color-picker/internal/color"
+)
+
+// Line based formats: kitty, Xresources and foot
+
+func encodeKitty(w io.Writer, t *color.TerminalTheme) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %s\n", t.Name)
+	fmt.Fprintf(bw, "foreground %s\n", t.Foreground)
+	fmt.Fprintf(bw, "background %s\n", t.Background)
+	fmt.Fprintf(bw, "cursor %s\n", t.Cursor)
+	fmt.Fprintf(bw, "cursor_text_color %s\n", t.Background)
+	for i, hex := range t.ANSI {
+		fmt.Fprintf(bw, "color%d %s\n", i, hex)
+	}
+	return bw.Flush()
+}
+
+func encodeXresources(w io.Writer, t *color.TerminalTheme) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "! %s\n", t.Name)
+	fmt.Fprintf(bw, "*.foreground: %s\n", t.Foreground)
+	fmt.Fprintf(bw, "*.background: %s\n", t.Background)
+	fmt.Fprintf(bw, "*.cursorColor: %s\n", t.Cursor)
+	for i, hex := range t.ANSI {
+		fmt.Fprintf(bw, "*.color%d: %s\n", i, hex)
+	}
+	return bw.Flush()
+}
+
+// encodeFoot writes the [colors] and [cursor] sections of foot.ini
+func encodeFoot(w io.Writer, t *color.TerminalTheme) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %s\n", t.Name)
+	fmt.Fprint(bw, "[cursor]\n")
+	fmt.Fprintf(bw, "color=%s %s\n", bare(t.Background), bare(t.Cursor))
+	fmt.Fprint(bw, "\n[colors]\n")
+	fmt.Fprintf(bw, "foreground=%s\n", bare(t.Foreground))
+	fmt.Fprintf(bw, "background=%s\n", bare(t.Background))
+	for i, hex := range t.ANSI[:8] {
+		fmt.Fprintf(bw, "regular%d=%s\n", i, bare(hex))
+	}
+	for i, hex := range t.ANSI[8:] {
+		fmt.Fprintf(bw, "bright%d=%s\n", i, bare(hex))
+	}
+	return bw.Flush()
+}
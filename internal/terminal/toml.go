@@ -0,0 +1,58 @@
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"ladle-color-picker/internal/color"
+)
+
+// TOML formats: Alacritty and wezterm color scheme files
+
+func encodeAlacritty(w io.Writer, t *color.TerminalTheme) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %s\n", t.Name)
+	fmt.Fprint(bw, "[colors.primary]\n")
+	fmt.Fprintf(bw, "background = %q\n", t.Background)
+	fmt.Fprintf(bw, "foreground = %q\n", t.Foreground)
+	fmt.Fprint(bw, "\n[colors.cursor]\n")
+	fmt.Fprintf(bw, "text = %q\n", t.Background)
+	fmt.Fprintf(bw, "cursor = %q\n", t.Cursor)
+
+	for _, section := range []struct {
+		name   string
+		colors []string
+	}{{"normal", t.ANSI[:8]}, {"bright", t.ANSI[8:]}} {
+		fmt.Fprintf(bw, "\n[colors.%s]\n", section.name)
+		for i, hex := range section.colors {
+			fmt.Fprintf(bw, "%s = %q\n", ansiNames[i], hex)
+		}
+	}
+	return bw.Flush()
+}
+
+// encodeWezTerm writes a scheme for wezterm's colors directory
+func encodeWezTerm(w io.Writer, t *color.TerminalTheme) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, "[colors]\n")
+	fmt.Fprintf(bw, "foreground = %q\n", t.Foreground)
+	fmt.Fprintf(bw, "background = %q\n", t.Background)
+	fmt.Fprintf(bw, "cursor_bg = %q\n", t.Cursor)
+	fmt.Fprintf(bw, "cursor_border = %q\n", t.Cursor)
+	fmt.Fprintf(bw, "cursor_fg = %q\n", t.Background)
+	fmt.Fprintf(bw, "ansi = [%s]\n", tomlStrings(t.ANSI[:8]))
+	fmt.Fprintf(bw, "brights = [%s]\n", tomlStrings(t.ANSI[8:]))
+	fmt.Fprint(bw, "\n[metadata]\n")
+	fmt.Fprintf(bw, "name = %q\n", t.Name)
+	return bw.Flush()
+}
+
+func tomlStrings(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
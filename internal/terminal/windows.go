@@ -0,0 +1,64 @@
+package terminal
+
+import (
+	"encoding/json"
+	"io"
+
+	"ladle-color-picker/internal/color"
+)
+
+// windowsScheme is an entry for the "schemes" list in Windows Terminal's settings.json
+type windowsScheme struct {
+	Name                string `json:"name"`
+	Background          string `json:"background"`
+	Foreground          string `json:"foreground"`
+	CursorColor         string `json:"cursorColor"`
+	SelectionBackground string `json:"selectionBackground"`
+	Black               string `json:"black"`
+	Red                 string `json:"red"`
+	Green               string `json:"green"`
+	Yellow              string `json:"yellow"`
+	Blue                string `json:"blue"`
+	Purple              string `json:"purple"`
+	Cyan                string `json:"cyan"`
+	White               string `json:"white"`
+	BrightBlack         string `json:"brightBlack"`
+	BrightRed           string `json:"brightRed"`
+	BrightGreen         string `json:"brightGreen"`
+	BrightYellow        string `json:"brightYellow"`
+	BrightBlue          string `json:"brightBlue"`
+	BrightPurple        string `json:"brightPurple"`
+	BrightCyan          string `json:"brightCyan"`
+	BrightWhite         string `json:"brightWhite"`
+}
+
+func encodeWindowsTerminal(w io.Writer, t *color.TerminalTheme) error {
+	a := t.ANSI
+	scheme := windowsScheme{
+		Name:                t.Name,
+		Background:          t.Background,
+		Foreground:          t.Foreground,
+		CursorColor:         t.Cursor,
+		SelectionBackground: a[8],
+		Black:               a[0],
+		Red:                 a[1],
+		Green:               a[2],
+		Yellow:              a[3],
+		Blue:                a[4],
+		Purple:              a[5],
+		Cyan:                a[6],
+		White:               a[7],
+		BrightBlack:         a[8],
+		BrightRed:           a[9],
+		BrightGreen:         a[10],
+		BrightYellow:        a[11],
+		BrightBlue:          a[12],
+		BrightPurple:        a[13],
+		BrightCyan:          a[14],
+		BrightWhite:         a[15],
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(scheme)
+}
@@ -0,0 +1,121 @@
+package theme
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"image/color"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+// sourceTemplate is the Go file written by GenerateSource
+var sourceTemplate = template.Must(template.New("theme").Parse(`// Code generated by Ladle from the {{printf "%q" .Palette}} palette. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+)
+
+var (
+	{{.Lower}}Light = map[fyne.ThemeColorName]color.Color{
+{{- range .Light}}
+		theme.{{.Ident}}: {{.Value}},
+{{- end}}
+	}
+	{{.Lower}}Dark = map[fyne.ThemeColorName]color.Color{
+{{- range .Dark}}
+		theme.{{.Ident}}: {{.Value}},
+{{- end}}
+	}
+)
+
+// {{.Type}} is a fyne.Theme with light and dark variants. Colors that are
+// not listed come from fyne's default theme.
+type {{.Type}} struct{}
+
+var _ fyne.Theme = {{.Type}}{}
+
+// Color returns the palette color for the variant
+func ({{.Type}}) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	colors := {{.Lower}}Light
+	if variant == theme.VariantDark {
+		colors = {{.Lower}}Dark
+	}
+	if c, ok := colors[name]; ok {
+		return c
+	}
+	return theme.DefaultTheme().Color(name, variant)
+}
+
+// Font returns the default fonts
+func ({{.Type}}) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+// Icon returns the default icons
+func ({{.Type}}) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+// Size returns the default sizes
+func ({{.Type}}) Size(name fyne.ThemeSizeName) float32 {
+	return theme.DefaultTheme().Size(name)
+}
+`))
+
+type sourceEntry struct {
+	Ident string
+	Value string
+}
+
+// GenerateSource writes a Go file declaring typeName in package pkg, a
+// fyne.Theme with the same colors as t
+func GenerateSource(t *PaletteTheme, paletteName, pkg, typeName string) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name %q", pkg)
+	}
+	if !token.IsIdentifier(typeName) || !token.IsExported(typeName) {
+		return nil, fmt.Errorf("theme type name %q must be an exported Go identifier", typeName)
+	}
+
+	entries := func(variant fyne.ThemeVariant) []sourceEntry {
+		var out []sourceEntry
+		for _, role := range ColorRoles {
+			if c, ok := t.Resolve(role.Name, variant); ok {
+				n := color.NRGBAModel.Convert(c).(color.NRGBA)
+				out = append(out, sourceEntry{role.Ident, fmt.Sprintf("color.NRGBA{R: 0x%02x, G: 0x%02x, B: 0x%02x, A: 0x%02x}", n.R, n.G, n.B, n.A)})
+			}
+		}
+		return out
+	}
+
+	var buf bytes.Buffer
+	err := sourceTemplate.Execute(&buf, map[string]interface{}{
+		"Palette": paletteName,
+		"Package": pkg,
+		"Type":    typeName,
+		"Lower":   lowerFirst(typeName),
+		"Light":   entries(theme.VariantLight),
+		"Dark":    entries(theme.VariantDark),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// lowerFirst names the unexported color maps after the type
+func lowerFirst(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToLower(r)) + s[size:]
+}
@@ -0,0 +1,208 @@
+package theme
+
+import (
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	ladlecolor "ladle-color-picker/internal/color"
+)
+
+// Role tags on saved colors. "fyne:primary" assigns the color to the primary
+// role of both variants, "fyne-light:background" only to the light one.
+const (
+	RoleTagPrefix      = "fyne:"
+	LightRoleTagPrefix = "fyne-light:"
+	DarkRoleTagPrefix  = "fyne-dark:"
+)
+
+// ColorRole is a fyne theme color that can be assigned from a palette
+type ColorRole struct {
+	Name  fyne.ThemeColorName
+	Ident string //The theme package constant, used by the source generator
+}
+
+// ColorRoles lists the assignable fyne colors
+var ColorRoles = []ColorRole{
+	{theme.ColorNameBackground, "ColorNameBackground"},
+	{theme.ColorNameForeground, "ColorNameForeground"},
+	{theme.ColorNamePrimary, "ColorNamePrimary"},
+	{theme.ColorNameButton, "ColorNameButton"},
+	{theme.ColorNameDisabledButton, "ColorNameDisabledButton"},
+	{theme.ColorNameDisabled, "ColorNameDisabled"},
+	{theme.ColorNameError, "ColorNameError"},
+	{theme.ColorNameFocus, "ColorNameFocus"},
+	{theme.ColorNameHeaderBackground, "ColorNameHeaderBackground"},
+	{theme.ColorNameHover, "ColorNameHover"},
+	{theme.ColorNameHyperlink, "ColorNameHyperlink"},
+	{theme.ColorNameInputBackground, "ColorNameInputBackground"},
+	{theme.ColorNameInputBorder, "ColorNameInputBorder"},
+	{theme.ColorNameMenuBackground, "ColorNameMenuBackground"},
+	{theme.ColorNameOverlayBackground, "ColorNameOverlayBackground"},
+	{theme.ColorNamePlaceHolder, "ColorNamePlaceHolder"},
+	{theme.ColorNamePressed, "ColorNamePressed"},
+	{theme.ColorNameScrollBar, "ColorNameScrollBar"},
+	{theme.ColorNameSelection, "ColorNameSelection"},
+	{theme.ColorNameSeparator, "ColorNameSeparator"},
+	{theme.ColorNameShadow, "ColorNameShadow"},
+	{theme.ColorNameSuccess, "ColorNameSuccess"},
+	{theme.ColorNameWarning, "ColorNameWarning"},
+}
+
+// Roles maps fyne color names to the colors assigned to them
+type Roles map[fyne.ThemeColorName]color.Color
+
+// PaletteTheme is a fyne.Theme built from palette colors. Roles without an
+// assignment fall back to fyne's default theme for the requested variant.
+type PaletteTheme struct {
+	Light Roles
+	Dark  Roles
+}
+
+// NewPaletteTheme builds a theme from the role tags of a palette's colors.
+// A palette without any role tags is assigned automatically: the lightest
+// and darkest colors become the backgrounds, the color contrasting most with
+// each background its foreground, and the most colorful color the primary.
+func NewPaletteTheme(named *ladlecolor.NamedPalette) *PaletteTheme {
+	t := &PaletteTheme{Light: Roles{}, Dark: Roles{}}
+
+	tagged := false
+	for _, saved := range named.Colors {
+		col, err := ladlecolor.NewColorHex(saved.Hex)
+		if err != nil {
+			continue
+		}
+		for _, tag := range saved.Tags {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			for _, target := range []struct {
+				prefix string
+				roles  []Roles
+			}{
+				{RoleTagPrefix, []Roles{t.Light, t.Dark}},
+				{LightRoleTagPrefix, []Roles{t.Light}},
+				{DarkRoleTagPrefix, []Roles{t.Dark}},
+			} {
+				if name, ok := roleFromTag(tag, target.prefix); ok {
+					for _, roles := range target.roles {
+						roles[name] = col.ToFyneColor()
+					}
+					tagged = true
+				}
+			}
+		}
+	}
+
+	if !tagged {
+		t.autoAssign(named)
+	}
+	return t
+}
+
+// roleFromTag matches a tag like "fyne:primary" against the known roles, ignoring case
+func roleFromTag(tag, prefix string) (fyne.ThemeColorName, bool) {
+	if !strings.HasPrefix(tag, prefix) {
+		return "", false
+	}
+	for _, role := range ColorRoles {
+		if strings.EqualFold(string(role.Name), tag[len(prefix):]) {
+			return role.Name, true
+		}
+	}
+	return "", false
+}
+
+func (t *PaletteTheme) autoAssign(named *ladlecolor.NamedPalette) {
+	var colors []*ladlecolor.Color
+	for _, saved := range named.Colors {
+		if col, err := ladlecolor.NewColorHex(saved.Hex); err == nil {
+			colors = append(colors, col)
+		}
+	}
+	if len(colors) == 0 {
+		return
+	}
+
+	best := func(score func(c *ladlecolor.Color) float64) *ladlecolor.Color {
+		pick := colors[0]
+		for _, c := range colors[1:] {
+			if score(c) > score(pick) {
+				pick = c
+			}
+		}
+		return pick
+	}
+	lightness := func(c *ladlecolor.Color) float64 { return c.To(ladlecolor.OKLab)[0] }
+
+	lightBG := best(lightness)
+	darkBG := best(func(c *ladlecolor.Color) float64 { return -lightness(c) })
+	primary := best(func(c *ladlecolor.Color) float64 { return c.To(ladlecolor.OKLCH)[1] })
+
+	for _, v := range []struct {
+		roles Roles
+		bg    *ladlecolor.Color
+	}{{t.Light, lightBG}, {t.Dark, darkBG}} {
+		bg := v.bg
+		fg := best(func(c *ladlecolor.Color) float64 { return ladlecolor.Distance(c, bg) })
+		v.roles[theme.ColorNameBackground] = bg.ToFyneColor()
+		if fg != bg {
+			v.roles[theme.ColorNameForeground] = fg.ToFyneColor()
+		}
+		if primary != bg {
+			v.roles[theme.ColorNamePrimary] = primary.ToFyneColor()
+		}
+	}
+}
+
+// Resolve returns the color assigned to a role for the variant, if any
+func (t *PaletteTheme) Resolve(name fyne.ThemeColorName, variant fyne.ThemeVariant) (color.Color, bool) {
+	roles := t.Light
+	if variant == theme.VariantDark {
+		roles = t.Dark
+	}
+	c, ok := roles[name]
+	return c, ok
+}
+
+// Color returns the assigned color or fyne's default
+func (t *PaletteTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	if c, ok := t.Resolve(name, variant); ok {
+		return c
+	}
+	return theme.DefaultTheme().Color(name, variant)
+}
+
+// Font returns the default fonts
+func (t *PaletteTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return theme.DefaultTheme().Font(style)
+}
+
+// Icon returns the default icons
+func (t *PaletteTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return theme.DefaultTheme().Icon(name)
+}
+
+// Size returns the default sizes
+func (t *PaletteTheme) Size(name fyne.ThemeSizeName) float32 {
+	return theme.DefaultTheme().Size(name)
+}
+
+// ForMode pins the theme to one variant, the way LadleTheme follows its mode
+// rather than the system setting
+func (t *PaletteTheme) ForMode(mode ThemeMode) fyne.Theme {
+	variant := theme.VariantLight
+	if mode == Dark {
+		variant = theme.VariantDark
+	}
+	return &fixedVariant{PaletteTheme: t, variant: variant}
+}
+
+type fixedVariant struct {
+	*PaletteTheme
+	variant fyne.ThemeVariant
+}
+
+func (f *fixedVariant) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return f.PaletteTheme.Color(name, f.variant)
+}
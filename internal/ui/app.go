@@ -4,9 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"time"
 
 	"ladle-color-picker/internal/color"
+	"ladle-color-picker/internal/storage"
 	ladleTheme "ladle-color-picker/internal/theme"
+	"ladle-color-picker/internal/undo"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
@@ -15,11 +18,15 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
+// paletteSaveDelay is how long edits are batched before the palette is written
+const paletteSaveDelay = 500 * time.Millisecond
+
 // ColorPicker represents the main application
 type ColorPicker struct {
 	app          fyne.App
 	window       fyne.Window
 	currentColor *color.Color
+	wideColor    *color.WideColor
 	palette      *color.Palette
 	components   *Components
 	currentTheme *ladleTheme.LadleTheme
@@ -27,6 +34,17 @@ type ColorPicker struct {
 	animatedBg   *ladleTheme.AnimatedBG
 	isUpdating   bool
 
+	templates      []*color.Template
+	activeTemplate string
+
+	terminalTheme *color.TerminalTheme
+	editorTheme   *color.EditorTheme
+
+	history     *undo.Stack
+	sliderStart string
+
+	paletteWriter *storage.DebouncedWriter
+
 	themeToggleBtn *widget.Button
 }
 
@@ -50,6 +68,11 @@ func NewColorPicker() *ColorPicker {
 		currentColor: color.NewColor(255, 0, 0),
 		palette:      color.NewPalette(),
 		components:   NewComponents(),
+		history:      undo.NewStack(100),
+
+		terminalTheme: color.NewTerminalTheme(),
+		editorTheme:   color.NewEditorTheme(),
+		paletteWriter: storage.NewDebouncedWriter(paletteSaveDelay, color.WritePaletteFile),
 	}
 }
 
@@ -59,6 +82,9 @@ func (app *ColorPicker) Run() error {
 	if err := app.palette.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
 		fmt.Printf("could not load palette: %v\n", err)
 	}
+	app.loadTemplates()
+	app.loadTerminalTheme()
+	app.loadEditorTheme()
 
 	// Setup UI
 	app.setupUI()
@@ -66,14 +92,21 @@ func (app *ColorPicker) Run() error {
 	app.setupThemeEvents()
 
 	app.setupExtendedEvents()
+	app.setupTemplateShortcut()
+	app.setupHistoryEvents()
+	app.setupPasteShortcut()
+	app.setupTerminalThemeEvents()
 
 	// Setup event handlers
 	app.setupAllEvents()
 	app.setupPaletteEvents()
+	app.setupPaletteSelectEvents()
 
 	// Called to refresh the UI initially
 	app.updateUI()
+	app.updatePaletteSelect()
 	app.updateSavedColors()
+	app.updateHistory()
 
 	app.window.ShowAndRun()
 
@@ -81,6 +114,8 @@ func (app *ColorPicker) Run() error {
 	if app.animatedBg != nil {
 		app.animatedBg.Stop()
 	}
+	app.endSliderEdit()
+	app.paletteWriter.Flush()
 
 	return nil
 }
@@ -120,6 +155,7 @@ func (app *ColorPicker) createMainLayout() fyne.CanvasObject {
 
 func (app *ColorPicker) updateColorDisplay() {
 	app.components.UpdateColorDisplay(app.currentColor)
+	app.components.UpdateGamut(app.currentColor, app.wideColor)
 }
 
 func (app *ColorPicker) toggleTheme() {
@@ -152,21 +188,64 @@ func (app *ColorPicker) copyToClipboard(text string) {
 	}
 }
 
-func (app *ColorPicker) applyColorHex(hex string) {
+// showCopyMenu pops up every registered formatter below the anchor
+func (app *ColorPicker) showCopyMenu(anchor fyne.CanvasObject) {
+	var items []*fyne.MenuItem
+	for _, f := range color.Formatters {
+		f := f
+		items = append(items, fyne.NewMenuItem(f.Name, func() {
+			app.copyToClipboard(f.Format(app.currentColor))
+		}))
+	}
+	items = append(items, app.templateMenuItems()...)
+
+	app.showMenuBelow(fyne.NewMenu("", items...), anchor)
+}
+
+// showMenuBelow pops up a menu right under the anchor object
+func (app *ColorPicker) showMenuBelow(menu *fyne.Menu, anchor fyne.CanvasObject) {
+	pos := app.app.Driver().AbsolutePositionForObject(anchor).Add(fyne.NewPos(0, anchor.Size().Height))
+	widget.ShowPopUpMenuAtPosition(menu, app.window.Canvas(), pos)
+}
+
+// applyColorHex picks a color; action tells the history log where it came from
+func (app *ColorPicker) applyColorHex(hex, action string) {
 	col, err := color.NewColorHex(hex)
 	if err != nil {
 		return
 	}
 
+	app.endSliderEdit()
+	app.recordColorChange("Pick", app.currentColor.ToHex(), hex)
 	app.currentColor = col
+	app.wideColor = nil
+	app.logHistory(action)
 	app.palette.AddRecent(hex)
 	app.updateUI()
 	app.savePalette()
 	app.updateSavedColors()
 }
 
+// applyWideColor picks a color given as CSS color(), keeping the
+// unclipped value around so the gamut warning stays accurate
+func (app *ColorPicker) applyWideColor(css, action string) {
+	wide, err := color.ParseCSSColor(css)
+	if err != nil {
+		app.showNotification(err.Error())
+		return
+	}
+
+	app.applyColorHex(wide.ToColor().ToHex(), action)
+	app.wideColor = wide
+	app.updateColorDisplay()
+}
+
+// savePalette queues the palette for writing; bursts of edits end up as one write
 func (app *ColorPicker) savePalette() {
-	if err := app.palette.Save(); err != nil {
+	data, err := app.palette.Encode()
+	if err != nil {
 		fmt.Printf("could not save palette: %v\n", err)
+		return
 	}
+	app.paletteWriter.Schedule(data)
 }
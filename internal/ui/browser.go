@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"ladle-color-picker/internal/color"
+)
+
+const historyDateLayout = "2006-01-02"
+
+// logHistory appends the current color to the long-term history
+func (app *ColorPicker) logHistory(action string) {
+	entry := color.HistoryEntry{Time: time.Now(), Hex: app.currentColor.ToHex(), Action: action}
+	if err := color.AppendHistory(entry); err != nil {
+		fmt.Printf("could not write history: %v\n", err)
+	}
+}
+
+func (app *ColorPicker) setupPasteShortcut() {
+	app.window.Canvas().AddShortcut(&fyne.ShortcutPaste{}, func(fyne.Shortcut) {
+		app.pasteColor()
+	})
+}
+
+// pasteColor picks a hex code or CSS color() from the clipboard
+func (app *ColorPicker) pasteColor() {
+	text := strings.TrimSpace(app.window.Clipboard().Content())
+	if strings.HasPrefix(strings.ToLower(text), "color(") {
+		app.applyWideColor(text, color.ActionPaste)
+		return
+	}
+
+	col, err := color.ParseHex(text)
+	if err != nil {
+		app.showNotification("Clipboard does not hold a color")
+		return
+	}
+	app.applyColorHex(col.ToHex(), color.ActionPaste)
+}
+
+// showHistoryBrowser lists the long-term history with date and hue filters
+func (app *ColorPicker) showHistoryBrowser() {
+	entries, err := color.LoadHistory()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		dialog.ShowError(err, app.window)
+		return
+	}
+
+	fromEntry := widget.NewEntry()
+	fromEntry.SetPlaceHolder(historyDateLayout)
+	toEntry := widget.NewEntry()
+	toEntry.SetPlaceHolder(historyDateLayout)
+	hueFrom := widget.NewEntry()
+	hueFrom.SetPlaceHolder("0")
+	hueTo := widget.NewEntry()
+	hueTo.SetPlaceHolder("360")
+	useHue := widget.NewCheck("Filter by hue", nil)
+	countLabel := widget.NewLabel("")
+
+	var shown []color.HistoryEntry
+	var d dialog.Dialog
+
+	list := widget.NewList(
+		func() int { return len(shown) },
+		func() fyne.CanvasObject {
+			swatch := canvas.NewRectangle(color.NewColor(0, 0, 0).ToFyneColor())
+			swatch.SetMinSize(fyne.NewSize(24, 24))
+			return container.NewBorder(nil, nil, swatch, widget.NewButton("Restore", nil), widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, obj fyne.CanvasObject) {
+			entry := shown[id]
+			row := obj.(*fyne.Container)
+			label := row.Objects[0].(*widget.Label)
+			swatch := row.Objects[1].(*canvas.Rectangle)
+			restore := row.Objects[2].(*widget.Button)
+
+			label.SetText(fmt.Sprintf("%s  %s  (%s)", entry.Time.Local().Format("2006-01-02 15:04"), entry.Hex, entry.Action))
+			if col, err := color.NewColorHex(entry.Hex); err == nil {
+				swatch.FillColor = col.ToFyneColor()
+				swatch.Refresh()
+			}
+			restore.OnTapped = func() {
+				app.applyColorHex(entry.Hex, color.ActionHistory)
+				d.Hide()
+			}
+		},
+	)
+
+	apply := func() {
+		filter := color.HistoryFilter{UseHue: useHue.Checked}
+		if t, err := time.ParseInLocation(historyDateLayout, strings.TrimSpace(fromEntry.Text), time.Local); err == nil {
+			filter.From = t
+		}
+		if t, err := time.ParseInLocation(historyDateLayout, strings.TrimSpace(toEntry.Text), time.Local); err == nil {
+			filter.To = t.Add(24*time.Hour - time.Nanosecond) //Inclusive end day
+		}
+		filter.HueFrom = parseDegrees(hueFrom.Text, 0)
+		filter.HueTo = parseDegrees(hueTo.Text, 360)
+
+		shown = color.FilterHistory(entries, filter)
+		countLabel.SetText(fmt.Sprintf("%d of %d entries", len(shown), len(entries)))
+		list.Refresh()
+	}
+	apply()
+
+	filters := widget.NewForm(
+		widget.NewFormItem("From", fromEntry),
+		widget.NewFormItem("To", toEntry),
+		widget.NewFormItem("Hue from", hueFrom),
+		widget.NewFormItem("Hue to", hueTo),
+		widget.NewFormItem("", useHue),
+	)
+	top := container.NewVBox(filters, container.NewBorder(nil, nil, nil, widget.NewButton("Apply Filters", apply), countLabel))
+
+	d = dialog.NewCustom("Color History", "Close", container.NewBorder(top, nil, nil, nil, list), app.window)
+	d.Resize(fyne.NewSize(480, 560))
+	d.Show()
+}
+
+// parseDegrees reads a hue entry, falling back to def when it is empty or invalid
+func parseDegrees(text string, def float64) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSpace(text), 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
@@ -1,6 +1,8 @@
 package ui
 
 import (
+	"fmt"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
@@ -16,15 +18,31 @@ type Components struct {
 	HexLabel      *widget.Label
 	RGBLabel      *widget.Label
 	HSLLabel      *widget.Label
+	TermLabel     *widget.Label
+	TermThemeBtn  *widget.Button
+	SpaceSelect   *widget.Select
+	WideLabel     *widget.Label
+	WideEntry     *widget.Entry
+	GamutLabel    *widget.Label
+	Spaces        []color.ColorSpace
+	SpaceLabels   []*widget.Label
 	RedSlider     *widget.Slider
 	GreenSlider   *widget.Slider
 	BlueSlider    *widget.Slider
 	CopyHexBtn    *widget.Button
 	CopyRGBBtn    *widget.Button
+	CopyAsBtn     *widget.Button
 	SaveBtn       *widget.Button
+	DetailsBtn    *widget.Button
 	PresetButtons []*widget.Button
 	RecentBox     *fyne.Container
+	BrowseBtn     *widget.Button
 	SavedBox      *fyne.Container
+	PaletteSelect *widget.Select
+	PaletteBtn    *widget.Button
+	UndoBtn       *widget.Button
+	RedoBtn       *widget.Button
+	HistoryBox    *fyne.Container
 }
 
 // New UI Components are created below
@@ -32,20 +50,53 @@ func NewComponents() *Components {
 	swatch := canvas.NewRectangle(color.NewColor(255, 0, 0).ToFyneColor())
 	swatch.SetMinSize(fyne.NewSize(200, 100))
 
+	spaceNames := make([]string, len(color.RGBSpaces))
+	for i, space := range color.RGBSpaces {
+		spaceNames[i] = space.Name()
+	}
+
+	wideEntry := widget.NewEntry()
+	wideEntry.SetPlaceHolder("color(display-p3 1 0.5 0)")
+
+	spaces := color.Spaces()
+	spaceLabels := make([]*widget.Label, len(spaces))
+	for i := range spaces {
+		spaceLabels[i] = widget.NewLabel("")
+	}
+
+	spaceSelect := widget.NewSelect(spaceNames, nil)
+	spaceSelect.Selected = color.DisplayP3.Name()
+
 	return &Components{
-		ColorDisplay: widget.NewCard("Current Color", "", container.NewCenter(swatch)),
-		ColorSwatch:  swatch,
-		HexLabel:     widget.NewLabel("HEX: #ff0000"),
-		RGBLabel:     widget.NewLabel("RGB: rgb(255, 0, 0)"),
-		HSLLabel:     widget.NewLabel("HSL: hsl(0, 100%, 50%)"),
-		RedSlider:    widget.NewSlider(0, 255),
-		GreenSlider:  widget.NewSlider(0, 255),
-		BlueSlider:   widget.NewSlider(0, 255),
-		CopyHexBtn:   widget.NewButton("Copy HEX", nil),
-		CopyRGBBtn:   widget.NewButton("Copy RGB", nil),
-		SaveBtn:      widget.NewButton("Save Color", nil),
-		RecentBox:    container.NewHBox(),
-		SavedBox:     container.NewHBox(),
+		ColorDisplay:  widget.NewCard("Current Color", "", container.NewCenter(swatch)),
+		ColorSwatch:   swatch,
+		HexLabel:      widget.NewLabel("HEX: #ff0000"),
+		RGBLabel:      widget.NewLabel("RGB: rgb(255, 0, 0)"),
+		HSLLabel:      widget.NewLabel("HSL: hsl(0, 100%, 50%)"),
+		TermLabel:     widget.NewLabel("Terminal: xterm-256 196, ANSI 9"),
+		TermThemeBtn:  widget.NewButton("Theme…", nil),
+		SpaceSelect:   spaceSelect,
+		WideLabel:     widget.NewLabel(""),
+		WideEntry:     wideEntry,
+		GamutLabel:    widget.NewLabel(""),
+		Spaces:        spaces,
+		SpaceLabels:   spaceLabels,
+		RedSlider:     widget.NewSlider(0, 255),
+		GreenSlider:   widget.NewSlider(0, 255),
+		BlueSlider:    widget.NewSlider(0, 255),
+		CopyHexBtn:    widget.NewButton("Copy HEX", nil),
+		CopyRGBBtn:    widget.NewButton("Copy RGB", nil),
+		CopyAsBtn:     widget.NewButton("Copy as…", nil),
+		SaveBtn:       widget.NewButton("Save Color", nil),
+		DetailsBtn:    widget.NewButton("Details…", nil),
+		RecentBox:     container.NewHBox(),
+		BrowseBtn:     widget.NewButton("History…", nil),
+		SavedBox:      container.NewHBox(),
+		PaletteSelect: widget.NewSelect(nil, nil),
+		PaletteBtn:    widget.NewButton("Manage…", nil),
+		UndoBtn:       widget.NewButton("↶ Undo", nil),
+		RedoBtn:       widget.NewButton("↷ Redo", nil),
+		HistoryBox:    container.NewVBox(),
 	}
 }
 
@@ -57,12 +108,23 @@ func (c *Components) CreateLayout(currentColor *color.Color, palette *color.Pale
 	c.BlueSlider.SetValue(float64(currentColor.B))
 	presetButtons := c.createPresetColors()
 
+	spaceItems := make([]fyne.CanvasObject, len(c.SpaceLabels))
+	for i, label := range c.SpaceLabels {
+		spaceItems[i] = label
+	}
+	allSpaces := widget.NewAccordion(widget.NewAccordionItem("All color spaces", container.NewVBox(spaceItems...)))
+
 	return container.NewVBox(
 		c.ColorDisplay,
 		widget.NewSeparator(),
 		c.HexLabel,
 		c.RGBLabel,
 		c.HSLLabel,
+		container.NewBorder(nil, nil, nil, c.TermThemeBtn, c.TermLabel),
+		container.NewBorder(nil, nil, c.SpaceSelect, nil, c.WideLabel),
+		container.NewBorder(nil, nil, widget.NewLabel("Wide gamut:"), nil, c.WideEntry),
+		c.GamutLabel,
+		allSpaces,
 		widget.NewSeparator(),
 		widget.NewLabel("🔴 Red:"),
 		c.RedSlider,
@@ -71,16 +133,19 @@ func (c *Components) CreateLayout(currentColor *color.Color, palette *color.Pale
 		widget.NewLabel("🔵 Blue:"),
 		c.BlueSlider,
 		widget.NewSeparator(),
-		container.NewHBox(c.CopyHexBtn, c.CopyRGBBtn, c.SaveBtn),
+		container.NewHBox(c.CopyHexBtn, c.CopyRGBBtn, c.CopyAsBtn, c.SaveBtn, c.DetailsBtn),
 		widget.NewSeparator(),
 		widget.NewLabel(" Preset Colors:"),
 		presetButtons,
 		widget.NewSeparator(),
-		widget.NewLabel(" Recent Colors:"),
+		container.NewBorder(nil, nil, widget.NewLabel(" Recent Colors:"), c.BrowseBtn),
 		c.RecentBox,
 		widget.NewSeparator(),
-		widget.NewLabel(" Saved Colors:"),
+		container.NewBorder(nil, nil, widget.NewLabel(" Saved Colors:"), c.PaletteBtn, c.PaletteSelect),
 		c.SavedBox,
+		widget.NewSeparator(),
+		container.NewHBox(c.UndoBtn, c.RedoBtn),
+		widget.NewAccordion(widget.NewAccordionItem("Edit History", c.HistoryBox)),
 	)
 }
 
@@ -105,7 +170,40 @@ func (c *Components) UpdateColorDisplay(col *color.Color) {
 	c.HexLabel.SetText("HEX: " + col.ToHex())
 	c.RGBLabel.SetText("RGB: " + col.ToRGB())
 	c.HSLLabel.SetText("HSL: " + col.ToHSL())
+	c.TermLabel.SetText(fmt.Sprintf("Terminal: xterm-256 %d, ANSI %d", col.NearestXterm256(), col.NearestANSI16()))
 	c.ColorDisplay.SetTitle("Current Color: " + col.ToHex())
 	c.ColorSwatch.FillColor = col.ToFyneColor()
 	c.ColorSwatch.Refresh()
+
+	for i, space := range c.Spaces {
+		c.SpaceLabels[i].SetText(space.Name() + ": " + col.Format(space))
+	}
+}
+
+// UpdateGamut shows the color in the selected RGB space and warns when
+// a wide-gamut pick has to be clipped to be displayed in sRGB
+func (c *Components) UpdateGamut(col *color.Color, wide *color.WideColor) {
+	space := selectedRGBSpace(c.SpaceSelect.Selected)
+	if wide == nil {
+		wide = col.In(space)
+	} else {
+		wide = wide.Convert(space)
+	}
+	c.WideLabel.SetText(wide.ToCSS())
+
+	if wide.InSRGBGamut() {
+		c.GamutLabel.SetText("")
+	} else {
+		c.GamutLabel.SetText("⚠️ Outside sRGB: the swatch shows the nearest displayable color")
+	}
+}
+
+// selectedRGBSpace maps a select option back to its space, defaulting to Display P3
+func selectedRGBSpace(name string) *color.RGBSpace {
+	for _, space := range color.RGBSpaces {
+		if space.Name() == name {
+			return space
+		}
+	}
+	return color.DisplayP3
 }
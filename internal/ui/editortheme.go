@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"ladle-color-picker/internal/color"
+	"ladle-color-picker/internal/editortheme"
+)
+
+// loadEditorTheme reads the editor theme being composed
+func (app *ColorPicker) loadEditorTheme() {
+	t, err := color.LoadEditorTheme()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("could not load editor theme: %v\n", err)
+		}
+		return
+	}
+	app.editorTheme = t
+}
+
+func (app *ColorPicker) saveEditorTheme() {
+	if err := color.SaveEditorTheme(app.editorTheme); err != nil {
+		fmt.Printf("could not save editor theme: %v\n", err)
+	}
+}
+
+// showEditorThemeDialog maps palette colors onto editor roles and exports
+// the result as a VS Code or Vim color scheme
+func (app *ColorPicker) showEditorThemeDialog() {
+	t := app.editorTheme
+	saved := app.palette.Saved()
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(t.Name)
+	nameEntry.OnChanged = func(name string) {
+		t.Name = strings.TrimSpace(name)
+		app.saveEditorTheme()
+	}
+
+	options := make([]string, len(saved))
+	for i, s := range saved {
+		options[i] = s.Label()
+		if s.Name != "" {
+			options[i] += " (" + s.Hex + ")"
+		}
+	}
+
+	swatches := make([]*canvas.Rectangle, len(color.EditorRoles))
+	selects := make([]*widget.Select, len(color.EditorRoles))
+	updating := false
+	refresh := func() {
+		updating = true
+		defer func() { updating = false }()
+		for i, role := range color.EditorRoles {
+			col := t.Role(role.ID)
+			swatches[i].FillColor = col.ToFyneColor()
+			swatches[i].Refresh()
+
+			selects[i].ClearSelected()
+			for j, s := range saved {
+				if s.Hex == col.ToHex() {
+					selects[i].SetSelectedIndex(j)
+				}
+			}
+			if selects[i].SelectedIndex() < 0 {
+				selects[i].PlaceHolder = col.ToHex()
+				selects[i].Refresh()
+			}
+		}
+	}
+
+	setRole := func(id, hex string) {
+		t.SetRole(id, hex)
+		app.saveEditorTheme()
+		refresh()
+	}
+
+	form := container.NewVBox()
+	for i, role := range color.EditorRoles {
+		role := role
+		swatches[i] = canvas.NewRectangle(t.Role(role.ID).ToFyneColor())
+		swatches[i].SetMinSize(fyne.NewSize(24, 24))
+		selects[i] = widget.NewSelect(options, nil)
+		sel := selects[i]
+		sel.OnChanged = func(string) {
+			if !updating && sel.SelectedIndex() >= 0 {
+				setRole(role.ID, saved[sel.SelectedIndex()].Hex)
+			}
+		}
+
+		setBtn := widget.NewButton("Set", func() { setRole(role.ID, app.currentColor.ToHex()) })
+		label := widget.NewLabel(role.Label)
+		form.Add(container.NewBorder(nil, nil, container.NewHBox(swatches[i], label), setBtn, sel))
+	}
+	refresh()
+
+	darkCheck := widget.NewCheck("Dark background", nil)
+	darkCheck.SetChecked(t.Dark())
+	autoBtn := widget.NewButton("Auto-assign from Palette", func() {
+		colors := make([]*color.Color, 0, len(saved))
+		for _, s := range saved {
+			if col, err := color.NewColorHex(s.Hex); err == nil {
+				colors = append(colors, col)
+			}
+		}
+		if len(colors) == 0 {
+			app.showNotification("Save some colors to the palette first")
+			return
+		}
+		t.AutoAssign(colors, darkCheck.Checked)
+		app.saveEditorTheme()
+		refresh()
+	})
+
+	var names []string
+	for _, e := range editortheme.Exporters {
+		names = append(names, e.Name)
+	}
+	formatSelect := widget.NewSelect(names, nil)
+	formatSelect.SetSelected(names[0])
+	exportBtn := widget.NewButton("Export…", func() {
+		if i := formatSelect.SelectedIndex(); i >= 0 {
+			app.exportEditorTheme(editortheme.Exporters[i])
+		}
+	})
+
+	help := widget.NewLabel("Choose a saved color for each role, or Set it to the current color.")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		widget.NewForm(widget.NewFormItem("Name", nameEntry)),
+		container.NewHBox(autoBtn, darkCheck),
+		widget.NewSeparator(),
+		form,
+		help,
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil, widget.NewLabel("Format:"), exportBtn, formatSelect),
+	)
+
+	d := dialog.NewCustom("Editor Theme", "Close", container.NewVScroll(content), app.window)
+	d.Resize(fyne.NewSize(520, 640))
+	d.Show()
+}
+
+// exportEditorTheme writes the editor theme with the given exporter
+func (app *ColorPicker) exportEditorTheme(e *editortheme.Exporter) {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		if writer == nil {
+			return //cancelled
+		}
+		defer writer.Close()
+
+		if err := e.Encode(writer, app.editorTheme); err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		app.showNotification("Exported " + writer.URI().Name())
+	}, app.window)
+	d.SetFileName(e.FileName(app.editorTheme))
+	d.SetFilter(storage.NewExtensionFileFilter([]string{e.Extension}))
+	d.Show()
+}
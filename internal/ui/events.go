@@ -1,6 +1,10 @@
 package ui
 
-import "fyne.io/fyne/v2/widget"
+import (
+	"fyne.io/fyne/v2/widget"
+
+	"ladle-color-picker/internal/color"
+)
 
 // The function below sets up all event handlers
 func (app *ColorPicker) setupAllEvents() {
@@ -9,6 +13,7 @@ func (app *ColorPicker) setupAllEvents() {
 		if app.isUpdating {
 			return
 		}
+		app.beginSliderEdit()
 		app.currentColor.R = uint8(value)
 		app.afterColorChange()
 	}
@@ -17,6 +22,7 @@ func (app *ColorPicker) setupAllEvents() {
 		if app.isUpdating {
 			return
 		}
+		app.beginSliderEdit()
 		app.currentColor.G = uint8(value)
 		app.afterColorChange()
 	}
@@ -25,16 +31,33 @@ func (app *ColorPicker) setupAllEvents() {
 		if app.isUpdating {
 			return
 		}
+		app.beginSliderEdit()
 		app.currentColor.B = uint8(value)
 		app.afterColorChange()
 	}
+
+	// A drag becomes one undo step when it ends
+	for _, slider := range []*widget.Slider{app.components.RedSlider, app.components.GreenSlider, app.components.BlueSlider} {
+		slider.OnChangeEnded = func(float64) {
+			app.endSliderEdit()
+		}
+	}
+
+	// Wide gamut events
+	app.components.SpaceSelect.OnChanged = func(string) {
+		app.updateColorDisplay()
+	}
+
+	app.components.WideEntry.OnSubmitted = func(text string) {
+		app.applyWideColor(text, color.ActionWide)
+	}
 }
 
 func (app *ColorPicker) setupPaletteEvents() {
 	for _, btn := range app.components.PresetButtons {
 		hex := btn.Text
 		btn.OnTapped = func() {
-			app.applyColorHex(hex)
+			app.applyColorHex(hex, color.ActionPreset)
 		}
 	}
 }
@@ -48,13 +71,7 @@ func (app *ColorPicker) setupThemeEvents() {
 func (app *ColorPicker) setupExtendedEvents() {
 	// Save button event
 	app.components.SaveBtn.OnTapped = func() {
-		if app.palette.AddSaved(app.currentColor.ToHex()) {
-			app.updateSavedColors()
-			app.savePalette()
-			app.showNotification("Color saved to palette!")
-		} else {
-			app.showNotification("Color already saved!")
-		}
+		app.saveCurrentColor(false)
 	}
 
 	// Copy button events
@@ -65,11 +82,23 @@ func (app *ColorPicker) setupExtendedEvents() {
 	app.components.CopyRGBBtn.OnTapped = func() {
 		app.copyToClipboard(app.currentColor.ToRGB())
 	}
+
+	app.components.BrowseBtn.OnTapped = func() {
+		app.showHistoryBrowser()
+	}
+
+	app.components.DetailsBtn.OnTapped = func() {
+		app.showSavedDetails()
+	}
+
+	app.components.CopyAsBtn.OnTapped = func() {
+		app.showCopyMenu(app.components.CopyAsBtn)
+	}
 }
 
 // updateUI updates all UI elements
 func (app *ColorPicker) updateUI() {
-	app.components.UpdateColorDisplay(app.currentColor)
+	app.updateColorDisplay()
 	app.updateSliders()
 }
 
@@ -94,17 +123,16 @@ func (app *ColorPicker) updateSavedColors() {
 	for _, hex := range app.palette.RecentColors {
 		hex := hex
 		btn := app.makeColorButton(hex)
-		btn.OnTapped = func() { app.applyColorHex(hex) }
+		btn.OnTapped = func() { app.applyColorHex(hex, color.ActionRecent) }
 		app.components.RecentBox.Add(btn)
 	}
 	app.components.RecentBox.Refresh()
 
 	app.components.SavedBox.Objects = nil
-	for _, hex := range app.palette.SavedColors {
-		hex := hex
-		btn := app.makeColorButton(hex)
-		btn.OnTapped = func() { app.applyColorHex(hex) }
-		app.components.SavedBox.Add(btn)
+	for i, saved := range app.palette.Saved() {
+		hex := saved.Hex
+		tapped := func() { app.applyColorHex(hex, color.ActionSaved) }
+		app.components.SavedBox.Add(newSavedSwatch(saved.Label(), i, tapped, app.moveSaved))
 	}
 	app.components.SavedBox.Refresh()
 }
@@ -113,8 +141,15 @@ func (app *ColorPicker) makeColorButton(hex string) *widget.Button {
 	return widget.NewButton(hex, nil)
 }
 
+// afterColorChange only refreshes the display; the color becomes a
+// recent color once the slider drag is committed
 func (app *ColorPicker) afterColorChange() {
+	app.wideColor = nil
 	app.updateColorDisplay()
+}
+
+// commitColor records the current color as a recent pick
+func (app *ColorPicker) commitColor() {
 	app.palette.AddRecent(app.currentColor.ToHex())
 	app.savePalette()
 	app.updateSavedColors()
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	ladleTheme "ladle-color-picker/internal/theme"
+)
+
+// showFyneThemeDialog previews the active palette as the app theme and
+// generates a Go file implementing it for other fyne apps
+func (app *ColorPicker) showFyneThemeDialog() {
+	active := app.palette.Active()
+
+	pkgEntry := widget.NewEntry()
+	pkgEntry.SetText("theme")
+	typeEntry := widget.NewEntry()
+	typeEntry.SetText(themeTypeName(active.Name))
+
+	previewCheck := widget.NewCheck("Preview in Ladle", func(on bool) {
+		if on {
+			app.app.Settings().SetTheme(ladleTheme.NewPaletteTheme(active).ForMode(app.themeMode))
+		} else {
+			app.app.Settings().SetTheme(app.currentTheme)
+		}
+		app.window.Content().Refresh()
+	})
+
+	generateBtn := widget.NewButton("Generate Go File…", func() {
+		src, err := ladleTheme.GenerateSource(ladleTheme.NewPaletteTheme(active), active.Name,
+			strings.TrimSpace(pkgEntry.Text), strings.TrimSpace(typeEntry.Text))
+		if err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+
+		d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, app.window)
+				return
+			}
+			if writer == nil {
+				return //cancelled
+			}
+			defer writer.Close()
+
+			if _, err := writer.Write(src); err != nil {
+				dialog.ShowError(err, app.window)
+				return
+			}
+			app.showNotification("Generated " + writer.URI().Name())
+		}, app.window)
+		d.SetFileName(strings.ToLower(typeEntry.Text) + ".go")
+		d.SetFilter(storage.NewExtensionFileFilter([]string{".go"}))
+		d.Show()
+	})
+
+	help := widget.NewLabel("Assign colors to theme roles with tags in a color's details: " +
+		"\"fyne:primary\" for both variants, or \"fyne-light:background\" and \"fyne-dark:background\" for one. " +
+		"Untagged palettes are assigned automatically.")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		help,
+		widget.NewForm(
+			widget.NewFormItem("Package", pkgEntry),
+			widget.NewFormItem("Type", typeEntry),
+		),
+		container.NewHBox(previewCheck, generateBtn),
+	)
+
+	d := dialog.NewCustom("Fyne Theme", "Close", content, app.window)
+	d.SetOnClosed(func() {
+		if previewCheck.Checked {
+			previewCheck.SetChecked(false)
+		}
+	})
+	d.Resize(fyne.NewSize(450, 300))
+	d.Show()
+}
+
+// themeTypeName turns a palette name into an exported Go type name like "BrandTheme"
+func themeTypeName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if b.Len() == 0 && unicode.IsDigit(r) {
+			b.WriteString("Palette")
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String() + "Theme"
+}
@@ -0,0 +1,150 @@
+package ui
+
+import (
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"ladle-color-picker/internal/color"
+	"ladle-color-picker/internal/undo"
+)
+
+func (app *ColorPicker) setupHistoryEvents() {
+	app.history.OnChange = app.updateHistory
+
+	app.components.UndoBtn.OnTapped = func() {
+		app.history.Undo()
+	}
+
+	app.components.RedoBtn.OnTapped = func() {
+		app.history.Redo()
+	}
+
+	// Ctrl+Z undoes, Ctrl+Shift+Z redoes
+	app.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyZ,
+		Modifier: fyne.KeyModifierShortcutDefault,
+	}, func(fyne.Shortcut) {
+		app.history.Undo()
+	})
+
+	app.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyZ,
+		Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift,
+	}, func(fyne.Shortcut) {
+		app.history.Redo()
+	})
+}
+
+// recordColorChange adds a color change that has already been applied to the history
+func (app *ColorPicker) recordColorChange(label, before, after string) {
+	if before == after {
+		return
+	}
+
+	app.history.Push(&undo.FuncCommand{
+		Name:     label + " " + after,
+		DoFunc:   func() { app.setColor(after) },
+		UndoFunc: func() { app.setColor(before) },
+	})
+}
+
+// beginSliderEdit remembers the color a slider drag started from
+func (app *ColorPicker) beginSliderEdit() {
+	if app.sliderStart == "" {
+		app.sliderStart = app.currentColor.ToHex()
+	}
+}
+
+// endSliderEdit commits a slider edit: it becomes one recent color and one
+// undo step. Sliders call it through OnChangeEnded when a drag ends and after
+// every arrow key step, since SetValue fires OnChangeEnded too.
+//
+// Other actions call it first as well. A drag can still be open when a
+// shortcut fires or the window closes, and it must become its own step
+// before the action's change. Clearing sliderStart also keeps the
+// OnChangeEnded fired by updateSliders from logging the action's new color
+// as a slider adjustment.
+func (app *ColorPicker) endSliderEdit() {
+	start := app.sliderStart
+	app.sliderStart = ""
+	if start == "" || start == app.currentColor.ToHex() {
+		return
+	}
+	app.recordColorChange("Adjust", start, app.currentColor.ToHex())
+	app.logHistory(color.ActionSlider)
+	app.commitColor()
+}
+
+// setColor shows a color without touching recent colors or the history
+func (app *ColorPicker) setColor(hex string) {
+	col, err := color.NewColorHex(hex)
+	if err != nil {
+		return
+	}
+
+	app.currentColor = col
+	app.wideColor = nil
+	app.updateUI()
+}
+
+// errUnchanged lets a palette change report that it turned out to be a no-op
+var errUnchanged = errors.New("nothing changed")
+
+// editPalette applies a palette change as one undoable step. A change
+// returning errUnchanged records nothing.
+func (app *ColorPicker) editPalette(label string, change func() error) error {
+	before := app.palette.Snapshot()
+	if err := change(); err != nil {
+		app.palette.Restore(before)
+		if errors.Is(err, errUnchanged) {
+			return nil
+		}
+		return err
+	}
+	after := app.palette.Snapshot()
+
+	app.history.Push(&undo.FuncCommand{
+		Name: label,
+		DoFunc: func() {
+			app.palette.Restore(after)
+			app.afterPaletteChange()
+		},
+		UndoFunc: func() {
+			app.palette.Restore(before)
+			app.afterPaletteChange()
+		},
+	})
+	app.afterPaletteChange()
+	return nil
+}
+
+// updateHistory refreshes the history list and the undo/redo buttons
+func (app *ColorPicker) updateHistory() {
+	box := app.components.HistoryBox
+	box.Objects = nil
+
+	undone := app.history.Undone()
+	for i := len(undone) - 1; i >= 0; i-- {
+		label := widget.NewLabel("↷ " + undone[i])
+		label.Importance = widget.LowImportance
+		box.Add(label)
+	}
+	for _, done := range app.history.Done() {
+		box.Add(widget.NewLabel("• " + done))
+	}
+	box.Refresh()
+
+	if app.history.CanUndo() {
+		app.components.UndoBtn.Enable()
+	} else {
+		app.components.UndoBtn.Disable()
+	}
+	if app.history.CanRedo() {
+		app.components.RedoBtn.Enable()
+	} else {
+		app.components.RedoBtn.Disable()
+	}
+}
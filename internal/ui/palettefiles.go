@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+
+	"ladle-color-picker/internal/color"
+	"ladle-color-picker/internal/swatches"
+)
+
+// showImportDialog reads a palette file into a new palette and makes it active
+func (app *ColorPicker) showImportDialog() {
+	d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		if reader == nil {
+			return //cancelled
+		}
+		defer reader.Close()
+
+		//Buffer the file so formats sharing an extension can each have a go
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		name := reader.URI().Name()
+		p, err := swatches.Decode(name, bytes.NewReader(data))
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %w", name, err), app.window)
+			return
+		}
+
+		named := p.ToNamed()
+		err = app.editPalette("Import "+name, func() error {
+			return app.palette.AddPalette(named)
+		})
+		if err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		app.showNotification(fmt.Sprintf("Imported %d colors into \"%s\"", len(named.Colors), named.Name))
+
+		//Imports are kept whole, but saving more needs a higher limit
+		if app.palette.MaxSaved != color.Unlimited && len(named.Colors) > app.palette.MaxSaved {
+			dialog.ShowInformation("Palette Over Limit", fmt.Sprintf(
+				"\"%s\" holds %d colors, more than the limit of %d per palette.\n"+
+					"Raise the limit in Settings to save more colors to it.",
+				named.Name, len(named.Colors), app.palette.MaxSaved), app.window)
+		}
+	}, app.window)
+	d.SetFilter(storage.NewExtensionFileFilter(swatches.ImportExtensions()))
+	d.Show()
+}
+
+// exportMenuItem offers every writable palette file format for the active palette
+func (app *ColorPicker) exportMenuItem() *fyne.MenuItem {
+	var items []*fyne.MenuItem
+	for _, format := range swatches.Exporters() {
+		format := format
+		items = append(items, fyne.NewMenuItem(format.Name+"…", func() {
+			app.showExportDialog(format)
+		}))
+	}
+
+	item := fyne.NewMenuItem("Export as", nil)
+	item.ChildMenu = fyne.NewMenu("", items...)
+	return item
+}
+
+// showExportDialog writes the active palette in the given format
+func (app *ColorPicker) showExportDialog(format *swatches.Format) {
+	active := app.palette.Active()
+
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		if writer == nil {
+			return //cancelled
+		}
+		defer writer.Close()
+
+		if err := format.Encode(writer, swatches.FromNamed(active)); err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		app.showNotification("Exported " + writer.URI().Name())
+	}, app.window)
+	d.SetFileName(active.Name + format.Extensions[0])
+	d.SetFilter(storage.NewExtensionFileFilter(format.Extensions))
+	d.Show()
+}
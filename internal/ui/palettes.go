@@ -0,0 +1,397 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+
+	"ladle-color-picker/internal/color"
+)
+
+func (app *ColorPicker) setupPaletteSelectEvents() {
+	app.components.PaletteSelect.OnChanged = func(name string) {
+		if app.isUpdating {
+			return
+		}
+		if err := app.palette.SwitchPalette(name); err != nil {
+			app.showNotification(err.Error())
+			return
+		}
+		app.afterPaletteChange()
+	}
+
+	app.components.PaletteBtn.OnTapped = func() {
+		app.showPaletteMenu(app.components.PaletteBtn)
+	}
+}
+
+// updatePaletteSelect syncs the selector with the palette list without triggering events
+func (app *ColorPicker) updatePaletteSelect() {
+	app.isUpdating = true
+	app.components.PaletteSelect.Options = app.palette.Names()
+	app.components.PaletteSelect.SetSelected(app.palette.Active().Name)
+	app.components.PaletteSelect.Refresh()
+	app.isUpdating = false
+}
+
+func (app *ColorPicker) afterPaletteChange() {
+	app.updatePaletteSelect()
+	app.updateSavedColors()
+	app.savePalette()
+}
+
+// showPaletteMenu pops up the palette management actions below the anchor
+func (app *ColorPicker) showPaletteMenu(anchor fyne.CanvasObject) {
+	active := app.palette.Active().Name
+
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem("New Palette…", func() {
+			app.askPaletteName("New Palette", "", app.palette.CreatePalette)
+		}),
+		fyne.NewMenuItem("Rename…", func() {
+			app.askPaletteName("Rename Palette", active, func(name string) error {
+				return app.palette.RenamePalette(active, name)
+			})
+		}),
+		fyne.NewMenuItem("Duplicate…", func() {
+			app.askPaletteName("Duplicate Palette", active+" copy", func(name string) error {
+				return app.palette.DuplicatePalette(active, name)
+			})
+		}),
+		app.sortMenuItem(),
+		fyne.NewMenuItem("Clean Up Similar Colors…", app.showCleanupDialog),
+		fyne.NewMenuItem("Settings…", app.showLimitsDialog),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Import…", app.showImportDialog),
+		app.exportMenuItem(),
+		fyne.NewMenuItem("Editor Theme…", app.showEditorThemeDialog),
+		fyne.NewMenuItem("Fyne Theme…", app.showFyneThemeDialog),
+		fyne.NewMenuItem("Swatch Sheet…", app.showSwatchSheetDialog),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem("Delete", func() {
+			dialog.ShowConfirm("Delete Palette", "Delete \""+active+"\" and its colors?", func(ok bool) {
+				if !ok {
+					return
+				}
+				err := app.editPalette("Delete palette "+active, func() error {
+					return app.palette.DeletePalette(active)
+				})
+				if err != nil {
+					dialog.ShowError(err, app.window)
+				}
+			}, app.window)
+		}),
+	)
+
+	app.showMenuBelow(menu, anchor)
+}
+
+// askPaletteName prompts for a palette name and applies it with the given action
+func (app *ColorPicker) askPaletteName(title, initial string, apply func(string) error) {
+	entry := widget.NewEntry()
+	entry.SetText(initial)
+
+	dialog.ShowForm(title, "OK", "Cancel", []*widget.FormItem{widget.NewFormItem("Name", entry)}, func(ok bool) {
+		if !ok {
+			return
+		}
+		err := app.editPalette(title+" "+strings.TrimSpace(entry.Text), func() error {
+			return apply(entry.Text)
+		})
+		if err != nil {
+			dialog.ShowError(err, app.window)
+		}
+	}, app.window)
+}
+
+// showSavedDetails edits the name, tags and notes of the current color in the active palette
+func (app *ColorPicker) showSavedDetails() {
+	hex := app.currentColor.ToHex()
+	saved := app.palette.Active().Find(hex)
+	if saved == nil {
+		app.showNotification("Save the color first to add details")
+		return
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(saved.Name)
+	nameEntry.SetPlaceHolder("Primary/Button")
+	tagsEntry := widget.NewEntry()
+	tagsEntry.SetText(strings.Join(saved.Tags, ", "))
+	tagsEntry.SetPlaceHolder("checkout, brand")
+	notesEntry := widget.NewMultiLineEntry()
+	notesEntry.SetText(saved.Notes)
+
+	const layout = "2006-01-02 15:04"
+	info := widget.NewLabel(fmt.Sprintf("Created %s · Updated %s · Source: %s",
+		saved.Created.Local().Format(layout), saved.Updated.Local().Format(layout), saved.Source))
+
+	var d dialog.Dialog
+	removeBtn := widget.NewButton("Remove From Palette", func() {
+		app.editPalette("Remove "+saved.Label(), func() error {
+			app.palette.RemoveSaved(hex)
+			return nil
+		})
+		d.Hide()
+	})
+
+	form := widget.NewForm(
+		widget.NewFormItem("Name", nameEntry),
+		widget.NewFormItem("Tags", tagsEntry),
+		widget.NewFormItem("Notes", notesEntry),
+	)
+
+	d = dialog.NewCustomConfirm(hex, "Save", "Cancel", container.NewVBox(form, info, removeBtn), func(ok bool) {
+		if !ok {
+			return
+		}
+		err := app.editPalette("Edit "+hex, func() error {
+			return app.palette.EditSaved(hex, nameEntry.Text, color.ParseTags(tagsEntry.Text), notesEntry.Text)
+		})
+		if err != nil {
+			dialog.ShowError(err, app.window)
+		}
+	}, app.window)
+	d.Resize(fyne.NewSize(400, 380))
+	d.Show()
+}
+
+// confirmMakeRoom asks before removing the oldest saved colors when the
+// palette is full. After lowering the limit it can take more than one.
+func (app *ColorPicker) confirmMakeRoom() {
+	active := app.palette.Active()
+	oldest := app.palette.OldestToMakeRoom()
+	if len(oldest) == 0 {
+		return
+	}
+
+	var labels []string
+	for i, saved := range oldest {
+		if i == 5 {
+			labels = append(labels, "…")
+			break
+		}
+		labels = append(labels, saved.Label())
+	}
+	what := fmt.Sprintf("the oldest one (%s)", labels[0])
+	if len(oldest) > 1 {
+		what = fmt.Sprintf("the %d oldest (%s)", len(oldest), strings.Join(labels, ", "))
+	}
+	msg := fmt.Sprintf("\"%s\" already holds %d colors, the limit is %d.\nRemove %s to make room?",
+		active.Name, len(active.Colors), app.palette.MaxSaved, what)
+
+	dialog.ShowConfirm("Palette Full", msg, func(ok bool) {
+		if !ok {
+			return
+		}
+		hex := app.currentColor.ToHex()
+		err := app.editPalette("Save "+hex, func() error {
+			for _, saved := range oldest {
+				app.palette.RemoveSaved(saved.Hex)
+			}
+			return app.palette.AddSaved(hex)
+		})
+		if err != nil {
+			dialog.ShowError(err, app.window)
+		}
+	}, app.window)
+}
+
+// showLimitsDialog edits how many recent and saved colors are kept and
+// how close two colors must be to count as near-duplicates
+func (app *ColorPicker) showLimitsDialog() {
+	recentEntry := widget.NewEntry()
+	recentEntry.SetText(strconv.Itoa(app.palette.MaxRecent))
+	savedEntry := widget.NewEntry()
+	savedEntry.SetText(strconv.Itoa(app.palette.MaxSaved))
+
+	unlimited := widget.NewCheck("Unlimited saved colors", func(on bool) {
+		if on {
+			savedEntry.Disable()
+		} else {
+			savedEntry.Enable()
+		}
+	})
+	unlimited.SetChecked(app.palette.MaxSaved == color.Unlimited)
+
+	thresholdEntry := widget.NewEntry()
+	thresholdEntry.SetText(strconv.FormatFloat(app.palette.DuplicateThreshold, 'f', -1, 64))
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Recent colors", recentEntry),
+		widget.NewFormItem("Saved per palette", savedEntry),
+		widget.NewFormItem("", unlimited),
+		widget.NewFormItem("Similar below", thresholdEntry),
+	}
+
+	dialog.ShowForm("Palette Settings", "Apply", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		maxRecent, err := strconv.Atoi(strings.TrimSpace(recentEntry.Text))
+		if err != nil {
+			dialog.ShowError(color.ErrInvalidLimit, app.window)
+			return
+		}
+		maxSaved := color.Unlimited
+		if !unlimited.Checked {
+			if maxSaved, err = strconv.Atoi(strings.TrimSpace(savedEntry.Text)); err != nil || maxSaved < 1 {
+				dialog.ShowError(color.ErrInvalidLimit, app.window)
+				return
+			}
+		}
+
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(thresholdEntry.Text), 64)
+		if err != nil {
+			dialog.ShowError(color.ErrInvalidThreshold, app.window)
+			return
+		}
+
+		if err := app.palette.SetLimits(maxRecent, maxSaved); err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		if err := app.palette.SetDuplicateThreshold(threshold); err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		app.afterPaletteChange()
+	}, app.window)
+}
+
+// moveSaved reorders the active palette after a swatch was dragged
+func (app *ColorPicker) moveSaved(from, to int) {
+	err := app.editPalette("Reorder colors", func() error {
+		moved, err := app.palette.MoveSaved(from, to)
+		if err == nil && !moved {
+			return errUnchanged
+		}
+		return err
+	})
+	if err != nil {
+		app.showNotification(err.Error())
+	}
+}
+
+// sortMenuItem offers a one-shot sort of the active palette for every sort key
+func (app *ColorPicker) sortMenuItem() *fyne.MenuItem {
+	var items []*fyne.MenuItem
+	for _, key := range color.SortKeys {
+		key := key
+		items = append(items, fyne.NewMenuItem(key.String(), func() {
+			app.editPalette("Sort by "+strings.ToLower(key.String()), func() error {
+				app.palette.SortSaved(key)
+				return nil
+			})
+		}))
+	}
+
+	item := fyne.NewMenuItem("Sort by", nil)
+	item.ChildMenu = fyne.NewMenu("", items...)
+	return item
+}
+
+// saveCurrentColor adds the current color to the active palette. Unless
+// force is set it first asks when a perceptually similar color is already saved.
+func (app *ColorPicker) saveCurrentColor(force bool) {
+	app.endSliderEdit()
+	hex := app.currentColor.ToHex()
+
+	if !force && app.palette.Active().Find(hex) == nil {
+		if matches := app.palette.NearDuplicates(hex); len(matches) > 0 {
+			msg := fmt.Sprintf("%s looks almost like the saved %s (distance %.3f).\nSave it anyway?",
+				hex, matches[0].Saved.Label(), matches[0].Distance)
+			dialog.ShowConfirm("Similar Color Saved", msg, func(ok bool) {
+				if ok {
+					app.saveCurrentColor(true)
+				}
+			}, app.window)
+			return
+		}
+	}
+
+	err := app.editPalette("Save "+hex, func() error {
+		return app.palette.AddSaved(hex)
+	})
+	switch {
+	case err == nil:
+		app.showNotification("Color saved to palette!")
+	case errors.Is(err, color.ErrPaletteFull):
+		app.confirmMakeRoom()
+	default:
+		app.showNotification("Color already saved!")
+	}
+}
+
+// showCleanupDialog proposes merging groups of near-duplicate saved colors
+func (app *ColorPicker) showCleanupDialog() {
+	thresholdEntry := widget.NewEntry()
+	threshold := app.palette.DuplicateThreshold
+	if threshold == 0 {
+		threshold = color.DefaultDuplicateThreshold
+	}
+	thresholdEntry.SetText(strconv.FormatFloat(threshold, 'f', -1, 64))
+
+	clusters := container.NewVBox()
+	var refresh func()
+	refresh = func() {
+		if v, err := strconv.ParseFloat(strings.TrimSpace(thresholdEntry.Text), 64); err == nil && v >= 0 {
+			threshold = v
+		}
+
+		clusters.Objects = nil
+		for _, group := range app.palette.ClusterSaved(threshold) {
+			keep := group[0]
+
+			//Every member is offered ticked; unticked ones stay in the palette
+			hexByLabel := make(map[string]string)
+			var labels []string
+			for _, saved := range group[1:] {
+				label := saved.Label()
+				if saved.Name != "" {
+					label += " (" + saved.Hex + ")"
+				}
+				hexByLabel[label] = saved.Hex
+				labels = append(labels, label)
+			}
+			members := widget.NewCheckGroup(labels, nil)
+			members.SetSelected(labels)
+
+			merge := widget.NewButton("Merge into "+keep.Label(), func() {
+				var others []string
+				for _, label := range members.Selected {
+					others = append(others, hexByLabel[label])
+				}
+				if len(others) == 0 {
+					return
+				}
+				err := app.editPalette("Merge into "+keep.Label(), func() error {
+					return app.palette.MergeSaved(keep.Hex, others)
+				})
+				if err != nil {
+					dialog.ShowError(err, app.window)
+				}
+				refresh()
+			})
+			clusters.Add(container.NewBorder(nil, nil, nil, merge, members))
+			clusters.Add(widget.NewSeparator())
+		}
+		if len(clusters.Objects) == 0 {
+			clusters.Add(widget.NewLabel("No similar colors found"))
+		}
+		clusters.Refresh()
+	}
+	refresh()
+
+	top := container.NewBorder(nil, nil, widget.NewLabel("Similar below"), widget.NewButton("Find", refresh), thresholdEntry)
+	d := dialog.NewCustom("Clean Up Similar Colors", "Close", container.NewBorder(top, nil, nil, nil, container.NewVScroll(clusters)), app.window)
+	d.Resize(fyne.NewSize(480, 400))
+	d.Show()
+}
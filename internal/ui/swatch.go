@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// savedSwatch is a saved color button that can be dragged sideways to a new position
+type savedSwatch struct {
+	widget.Button
+
+	index   int
+	dragX   float32
+	onMoved func(from, to int)
+}
+
+func newSavedSwatch(label string, index int, tapped func(), moved func(from, to int)) *savedSwatch {
+	s := &savedSwatch{index: index, onMoved: moved}
+	s.Text = label
+	s.OnTapped = tapped
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// Dragged accumulates the horizontal drag distance
+func (s *savedSwatch) Dragged(e *fyne.DragEvent) {
+	s.dragX += e.Dragged.DX
+}
+
+// DragEnd moves the color by as many slots as it was dragged across
+func (s *savedSwatch) DragEnd() {
+	slot := s.Size().Width + theme.Padding()
+	shift := int(math.Round(float64(s.dragX / slot)))
+	s.dragX = 0
+
+	if shift != 0 && s.onMoved != nil {
+		s.onMoved(s.index, s.index+shift)
+	}
+}
@@ -0,0 +1,128 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"ladle-color-picker/internal/color"
+	"ladle-color-picker/internal/swatchsheet"
+)
+
+// showSwatchSheetDialog renders the active palette as a PNG or SVG image of swatch cards
+func (app *ColorPicker) showSwatchSheetDialog() {
+	active := app.palette.Active()
+	opts := swatchsheet.DefaultOptions()
+
+	columnsEntry := widget.NewEntry()
+	columnsEntry.SetPlaceHolder("Auto")
+	if active.Columns > 0 {
+		columnsEntry.SetText(strconv.Itoa(active.Columns))
+	}
+
+	var layouts []string
+	for _, l := range swatchsheet.Layouts {
+		layouts = append(layouts, l.String())
+	}
+	layoutRadio := widget.NewRadioGroup(layouts, func(selected string) {
+		if selected == swatchsheet.Grid.String() {
+			columnsEntry.Enable()
+		} else {
+			columnsEntry.Disable()
+		}
+	})
+	layoutRadio.Horizontal = true
+	layoutRadio.Required = true
+	layoutRadio.SetSelected(opts.Layout.String())
+
+	titleCheck := widget.NewCheck("Palette name", nil)
+	titleCheck.SetChecked(opts.Title)
+	namesCheck := widget.NewCheck("Color names", nil)
+	namesCheck.SetChecked(opts.Names)
+
+	//The hex code is always on the card
+	var formats []string
+	for _, f := range color.Formatters {
+		if f.Name != "CSS HEX" {
+			formats = append(formats, f.Name)
+		}
+	}
+	formatsGroup := widget.NewCheckGroup(formats, nil)
+
+	scaleSelect := widget.NewSelect([]string{"1×", "2×", "3×"}, nil)
+	scaleSelect.SetSelected("2×")
+
+	var names []string
+	for _, e := range swatchsheet.Exporters {
+		names = append(names, e.Name)
+	}
+	formatSelect := widget.NewSelect(names, nil)
+	formatSelect.SetSelected(names[0])
+	exportBtn := widget.NewButton("Export…", func() {
+		i := formatSelect.SelectedIndex()
+		if i < 0 {
+			return
+		}
+		for j, l := range swatchsheet.Layouts {
+			if layouts[j] == layoutRadio.Selected {
+				opts.Layout = l
+			}
+		}
+		opts.Columns, _ = strconv.Atoi(strings.TrimSpace(columnsEntry.Text))
+		opts.Title = titleCheck.Checked
+		opts.Names = namesCheck.Checked
+		opts.Formats = formatsGroup.Selected
+		opts.Scale, _ = strconv.ParseFloat(strings.TrimSuffix(scaleSelect.Selected, "×"), 64)
+		app.exportSwatchSheet(swatchsheet.Exporters[i], active, opts)
+	})
+
+	content := container.NewVBox(
+		widget.NewForm(
+			widget.NewFormItem("Layout", layoutRadio),
+			widget.NewFormItem("Columns", columnsEntry),
+			widget.NewFormItem("Show", container.NewHBox(titleCheck, namesCheck)),
+			widget.NewFormItem("PNG scale", scaleSelect),
+		),
+		widget.NewLabel("Also label cards with:"),
+		formatsGroup,
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil, widget.NewLabel("Format:"), exportBtn, formatSelect),
+	)
+
+	d := dialog.NewCustom("Swatch Sheet", "Close", container.NewVScroll(content), app.window)
+	d.Resize(fyne.NewSize(450, 560))
+	d.Show()
+}
+
+// exportSwatchSheet writes the palette's swatch sheet with the given exporter
+func (app *ColorPicker) exportSwatchSheet(e *swatchsheet.Exporter, p *color.NamedPalette, opts swatchsheet.Options) {
+	if len(p.Colors) == 0 {
+		app.showNotification("\"" + p.Name + "\" has no colors to export")
+		return
+	}
+
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		if writer == nil {
+			return //cancelled
+		}
+		defer writer.Close()
+
+		if err := e.Encode(writer, p, opts); err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		app.showNotification("Exported " + writer.URI().Name())
+	}, app.window)
+	d.SetFileName(e.FileName(p))
+	d.SetFilter(storage.NewExtensionFileFilter([]string{e.Extension}))
+	d.Show()
+}
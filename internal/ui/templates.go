@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"ladle-color-picker/internal/color"
+)
+
+// loadTemplates reads the user's copy templates
+func (app *ColorPicker) loadTemplates() {
+	templates, active, err := color.LoadTemplates()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("could not load templates: %v\n", err)
+		}
+		return
+	}
+	app.templates = templates
+	app.activeTemplate = active
+}
+
+func (app *ColorPicker) saveTemplates() {
+	if err := color.SaveTemplates(app.templates, app.activeTemplate); err != nil {
+		fmt.Printf("could not save templates: %v\n", err)
+	}
+}
+
+// setTemplate adds a template or replaces the one with the same name
+func (app *ColorPicker) setTemplate(t *color.Template) {
+	for i, existing := range app.templates {
+		if existing.Name == t.Name {
+			app.templates[i] = t
+			app.saveTemplates()
+			return
+		}
+	}
+	app.templates = append(app.templates, t)
+	app.saveTemplates()
+}
+
+func (app *ColorPicker) removeTemplate(name string) {
+	for i, t := range app.templates {
+		if t.Name == name {
+			app.templates = append(app.templates[:i], app.templates[i+1:]...)
+			break
+		}
+	}
+	app.saveTemplates()
+}
+
+// copyWithTemplate copies the current color using the last used template
+func (app *ColorPicker) copyWithTemplate() {
+	if len(app.templates) == 0 {
+		app.showNotification("No copy templates defined")
+		return
+	}
+
+	t := app.templates[0]
+	for _, candidate := range app.templates {
+		if candidate.Name == app.activeTemplate {
+			t = candidate
+		}
+	}
+	app.copyToClipboard(t.Render(app.currentColor))
+}
+
+// templateMenuItems lists the templates for the copy menu
+func (app *ColorPicker) templateMenuItems() []*fyne.MenuItem {
+	items := []*fyne.MenuItem{fyne.NewMenuItemSeparator()}
+	for _, t := range app.templates {
+		t := t
+		items = append(items, fyne.NewMenuItem(t.Name, func() {
+			if app.activeTemplate != t.Name {
+				app.activeTemplate = t.Name
+				app.saveTemplates()
+			}
+			app.copyToClipboard(t.Render(app.currentColor))
+		}))
+	}
+	return append(items, fyne.NewMenuItem("Manage templates…", app.showTemplatesDialog))
+}
+
+func (app *ColorPicker) setupTemplateShortcut() {
+	// Ctrl+Shift+C copies with the last used template
+	app.window.Canvas().AddShortcut(&desktop.CustomShortcut{
+		KeyName:  fyne.KeyC,
+		Modifier: fyne.KeyModifierShortcutDefault | fyne.KeyModifierShift,
+	}, func(fyne.Shortcut) {
+		app.copyWithTemplate()
+	})
+}
+
+// showTemplatesDialog lets the user add, edit and delete copy templates
+func (app *ColorPicker) showTemplatesDialog() {
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder("Brand")
+	patternEntry := widget.NewEntry()
+	patternEntry.SetPlaceHolder("brand.color(0x{HEX})")
+
+	preview := widget.NewLabel("")
+	patternEntry.OnChanged = func(pattern string) {
+		t := &color.Template{Pattern: pattern}
+		preview.SetText("Preview: " + t.Render(app.currentColor))
+	}
+
+	list := container.NewVBox()
+	var refresh func()
+	refresh = func() {
+		list.Objects = nil
+		for _, t := range app.templates {
+			t := t
+			edit := widget.NewButton(t.Name, func() {
+				nameEntry.SetText(t.Name)
+				patternEntry.SetText(t.Pattern)
+			})
+			del := widget.NewButton("Delete", func() {
+				app.removeTemplate(t.Name)
+				refresh()
+			})
+			list.Add(container.NewBorder(nil, nil, nil, del, edit))
+		}
+		list.Refresh()
+	}
+	refresh()
+
+	saveBtn := widget.NewButton("Save Template", func() {
+		t := &color.Template{Name: strings.TrimSpace(nameEntry.Text), Pattern: patternEntry.Text}
+		if err := t.Validate(); err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		app.setTemplate(t)
+		refresh()
+	})
+
+	help := widget.NewLabel("Placeholders: {r} {g} {b} {a}, {rf} {gf} {bf} {af}, {hex} {HEX}, {h} {s} {l}, {ok_l} {ok_c} {ok_h}. Add precision like {ok_c:3}.")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		list,
+		widget.NewSeparator(),
+		widget.NewForm(
+			widget.NewFormItem("Name", nameEntry),
+			widget.NewFormItem("Pattern", patternEntry),
+		),
+		preview,
+		help,
+		saveBtn,
+	)
+
+	d := dialog.NewCustom("Copy Templates", "Close", content, app.window)
+	d.Resize(fyne.NewSize(450, 450))
+	d.Show()
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+
+	"ladle-color-picker/internal/color"
+	"ladle-color-picker/internal/terminal"
+)
+
+// loadTerminalTheme reads the terminal theme being edited
+func (app *ColorPicker) loadTerminalTheme() {
+	t, err := color.LoadTerminalTheme()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			fmt.Printf("could not load terminal theme: %v\n", err)
+		}
+		return
+	}
+	app.terminalTheme = t
+}
+
+func (app *ColorPicker) saveTerminalTheme() {
+	if err := color.SaveTerminalTheme(app.terminalTheme); err != nil {
+		fmt.Printf("could not save terminal theme: %v\n", err)
+	}
+}
+
+func (app *ColorPicker) setupTerminalThemeEvents() {
+	app.components.TermThemeBtn.OnTapped = app.showTerminalThemeDialog
+}
+
+// showTerminalThemeDialog edits the 16 ANSI colors plus background,
+// foreground and cursor, and exports the theme for a terminal emulator
+func (app *ColorPicker) showTerminalThemeDialog() {
+	t := app.terminalTheme
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(t.Name)
+	nameEntry.OnChanged = func(name string) {
+		t.Name = strings.TrimSpace(name)
+		app.saveTerminalTheme()
+	}
+
+	swatches := make([]*canvas.Rectangle, color.TerminalSlots)
+	hexLabels := make([]*widget.Label, color.TerminalSlots)
+	refresh := func() {
+		for slot := range swatches {
+			swatches[slot].FillColor = t.Slot(slot).ToFyneColor()
+			swatches[slot].Refresh()
+			hexLabels[slot].SetText(t.Slot(slot).ToHex())
+		}
+	}
+
+	row := func(slot int) fyne.CanvasObject {
+		swatches[slot] = canvas.NewRectangle(t.Slot(slot).ToFyneColor())
+		swatches[slot].SetMinSize(fyne.NewSize(24, 24))
+		hexLabels[slot] = widget.NewLabel("")
+
+		setBtn := widget.NewButton("Set", func() {
+			if err := t.SetSlot(slot, app.currentColor.ToHex()); err != nil {
+				dialog.ShowError(err, app.window)
+				return
+			}
+			app.saveTerminalTheme()
+			refresh()
+		})
+		pickBtn := widget.NewButton("Pick", func() {
+			app.applyColorHex(t.Slot(slot).ToHex(), color.ActionTheme)
+		})
+
+		name := widget.NewLabel(color.TerminalSlotNames[slot])
+		return container.NewBorder(nil, nil, container.NewHBox(swatches[slot], name), container.NewHBox(hexLabels[slot], setBtn, pickBtn))
+	}
+
+	//Normal and bright colors side by side, then the special slots
+	grid := container.NewGridWithColumns(2)
+	for i := 0; i < 8; i++ {
+		grid.Add(row(i))
+		grid.Add(row(i + 8))
+	}
+	special := container.NewGridWithColumns(2)
+	for slot := color.SlotBackground; slot < color.TerminalSlots; slot++ {
+		special.Add(row(slot))
+	}
+	refresh()
+
+	resetBtn := widget.NewButton("Reset to xterm Defaults", func() {
+		dialog.ShowConfirm("Reset Terminal Theme", "Replace every slot with the xterm default?", func(ok bool) {
+			if !ok {
+				return
+			}
+			defaults := color.NewTerminalTheme()
+			for slot := 0; slot < color.TerminalSlots; slot++ {
+				if err := t.SetSlot(slot, defaults.Slot(slot).ToHex()); err != nil {
+					dialog.ShowError(err, app.window)
+					break
+				}
+			}
+			app.saveTerminalTheme()
+			refresh()
+		}, app.window)
+	})
+
+	var names []string
+	for _, e := range terminal.Exporters {
+		names = append(names, e.Name)
+	}
+	formatSelect := widget.NewSelect(names, nil)
+	formatSelect.SetSelected(names[0])
+	exportBtn := widget.NewButton("Export…", func() {
+		if i := formatSelect.SelectedIndex(); i >= 0 {
+			app.exportTerminalTheme(terminal.Exporters[i])
+		}
+	})
+
+	help := widget.NewLabel("Set stores the current color in a slot; Pick loads a slot into the picker.")
+	help.Wrapping = fyne.TextWrapWord
+
+	content := container.NewVBox(
+		widget.NewForm(widget.NewFormItem("Name", nameEntry)),
+		grid,
+		widget.NewSeparator(),
+		special,
+		help,
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil, widget.NewLabel("Format:"), container.NewHBox(exportBtn, resetBtn), formatSelect),
+	)
+
+	d := dialog.NewCustom("Terminal Theme", "Close", container.NewVScroll(content), app.window)
+	d.Resize(fyne.NewSize(720, 620))
+	d.Show()
+}
+
+// exportTerminalTheme writes the terminal theme with the given exporter
+func (app *ColorPicker) exportTerminalTheme(e *terminal.Exporter) {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		if writer == nil {
+			return //cancelled
+		}
+		defer writer.Close()
+
+		if err := e.Encode(writer, app.terminalTheme); err != nil {
+			dialog.ShowError(err, app.window)
+			return
+		}
+		app.showNotification("Exported " + writer.URI().Name())
+	}, app.window)
+	d.SetFileName(app.terminalTheme.Name + e.Extension)
+	d.SetFilter(storage.NewExtensionFileFilter([]string{e.Extension}))
+	d.Show()
+}
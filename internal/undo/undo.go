@@ -0,0 +1,111 @@
+package undo
+
+// Command is a reversible edit
+type Command interface {
+	Do()
+	Undo()
+	Label() string
+}
+
+// FuncCommand builds a command from a pair of closures
+type FuncCommand struct {
+	Name     string
+	DoFunc   func()
+	UndoFunc func()
+}
+
+// Do applies the edit
+func (f *FuncCommand) Do() { f.DoFunc() }
+
+// Undo reverts the edit
+func (f *FuncCommand) Undo() { f.UndoFunc() }
+
+// Label describes the edit for the history list
+func (f *FuncCommand) Label() string { return f.Name }
+
+// Stack keeps the undo and redo history
+type Stack struct {
+	done   []Command
+	undone []Command
+	limit  int
+
+	// OnChange is called after every change to the history
+	OnChange func()
+}
+
+// NewStack creates a stack that remembers at most limit commands
+func NewStack(limit int) *Stack {
+	return &Stack{limit: limit}
+}
+
+// Push records a command that has already been applied
+func (s *Stack) Push(c Command) {
+	s.done = append(s.done, c)
+	if s.limit > 0 && len(s.done) > s.limit {
+		s.done = s.done[len(s.done)-s.limit:]
+	}
+	s.undone = nil
+	s.changed()
+}
+
+// Undo reverts the most recent command. It returns false when there is nothing to undo.
+func (s *Stack) Undo() bool {
+	if len(s.done) == 0 {
+		return false
+	}
+
+	c := s.done[len(s.done)-1]
+	s.done = s.done[:len(s.done)-1]
+	c.Undo()
+	s.undone = append(s.undone, c)
+	s.changed()
+	return true
+}
+
+// Redo reapplies the most recently undone command. It returns false when there is nothing to redo.
+func (s *Stack) Redo() bool {
+	if len(s.undone) == 0 {
+		return false
+	}
+
+	c := s.undone[len(s.undone)-1]
+	s.undone = s.undone[:len(s.undone)-1]
+	c.Do()
+	s.done = append(s.done, c)
+	s.changed()
+	return true
+}
+
+// CanUndo reports whether there is a command to undo
+func (s *Stack) CanUndo() bool {
+	return len(s.done) > 0
+}
+
+// CanRedo reports whether there is a command to redo
+func (s *Stack) CanRedo() bool {
+	return len(s.undone) > 0
+}
+
+// Done returns the labels of applied commands, most recent first
+func (s *Stack) Done() []string {
+	return labels(s.done)
+}
+
+// Undone returns the labels of undone commands, next to redo first
+func (s *Stack) Undone() []string {
+	return labels(s.undone)
+}
+
+func labels(commands []Command) []string {
+	out := make([]string, len(commands))
+	for i, c := range commands {
+		out[len(commands)-1-i] = c.Label()
+	}
+	return out
+}
+
+func (s *Stack) changed() {
+	if s.OnChange != nil {
+		s.OnChange()
+	}
+}